@@ -0,0 +1,147 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Guliveer/twitch-miner-go/internal/backtest"
+	"github.com/Guliveer/twitch-miner-go/internal/config"
+	"github.com/Guliveer/twitch-miner-go/internal/ledger"
+	"github.com/Guliveer/twitch-miner-go/internal/model"
+)
+
+// configScenario is the scenario name for the account's configured settings.
+const configScenario = "CONFIG"
+
+// runBacktest implements the "backtest" subcommand. It replays an account's
+// prediction ledger with the configured bet settings and with each listed
+// strategy, and writes the results as CSV or JSON. Returns the exit code.
+func runBacktest(args []string) int {
+	fs := flag.NewFlagSet("backtest", flag.ContinueOnError)
+	account := fs.String("account", "", "Account username whose ledger to replay")
+	ledgerPath := fs.String("ledger", "", "Path to a ledger file (default: the account's ledger)")
+	configDir := fs.String("config", config.DefaultConfigDir, "Path to the configuration directory")
+	strategies := fs.String("strategies", "SMART,MOST_VOTED,HIGH_ODDS", "Comma-separated strategies to compare")
+	format := fs.String("format", "csv", "Output format: csv or json")
+	output := fs.String("output", "", "Output file (default: stdout)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if *account == "" && *ledgerPath == "" {
+		fmt.Fprintln(os.Stderr, "backtest: -account or -ledger is required")
+		return 2
+	}
+	if *format != "csv" && *format != "json" {
+		fmt.Fprintf(os.Stderr, "backtest: unknown format %q\n", *format)
+		return 2
+	}
+
+	path := *ledgerPath
+	if path == "" {
+		path = ledger.Path(*account)
+	}
+	entries, err := ledger.Load(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "backtest: %v\n", err)
+		return 1
+	}
+
+	cfg, err := loadBacktestConfig(*configDir, *account)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "backtest: %v\n", err)
+		return 1
+	}
+	configured := configuredBetSettings(cfg)
+	accountFloor := cfg.Bankroll.ToBankrollSettings(model.BankrollSettings{}).BalanceFloor
+
+	scenarios := []backtest.Scenario{{Name: configScenario, SettingsFor: configured, AccountBalanceFloor: accountFloor}}
+	for _, name := range strings.Split(*strategies, ",") {
+		name = strings.ToUpper(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		strategy := model.ParseStrategy(name)
+		if strategy.String() != name {
+			fmt.Fprintf(os.Stderr, "backtest: unknown strategy %q\n", name)
+			return 2
+		}
+		scenarios = append(scenarios, backtest.Scenario{
+			Name: name,
+			SettingsFor: func(streamer string) *model.BetSettings {
+				settings := *configured(streamer)
+				settings.Strategy = strategy
+				return &settings
+			},
+			AccountBalanceFloor: accountFloor,
+		})
+	}
+
+	results := backtest.Run(entries, scenarios)
+
+	out := io.Writer(os.Stdout)
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "backtest: %v\n", err)
+			return 1
+		}
+		defer f.Close()
+		out = f
+	}
+
+	if *format == "json" {
+		err = backtest.WriteJSON(out, results)
+	} else {
+		err = backtest.WriteCSV(out, results)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "backtest: writing results: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// loadBacktestConfig loads the account's config if it exists. Without one,
+// the default settings are used as the configured scenario.
+func loadBacktestConfig(dir, account string) (*config.AccountConfig, error) {
+	if account == "" {
+		return &config.AccountConfig{}, nil
+	}
+	for _, ext := range []string{".yaml", ".yml"} {
+		path := filepath.Join(dir, account+ext)
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		cfg, err := config.LoadAccountConfig(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := config.Validate(cfg); err != nil {
+			return nil, err
+		}
+		return cfg, nil
+	}
+	return &config.AccountConfig{}, nil
+}
+
+// configuredBetSettings resolves per-streamer bet settings the same way the
+// miner does: streamer overrides on top of streamer_defaults.
+func configuredBetSettings(cfg *config.AccountConfig) func(string) *model.BetSettings {
+	defaults := cfg.StreamerDefaults.ToStreamerSettings(model.DefaultStreamerSettings())
+	perStreamer := make(map[string]*model.BetSettings, len(cfg.Streamers))
+	for _, sc := range cfg.Streamers {
+		perStreamer[strings.ToLower(sc.Username)] = sc.Settings.ToStreamerSettings(defaults).Bet
+	}
+
+	return func(streamer string) *model.BetSettings {
+		if settings, ok := perStreamer[streamer]; ok {
+			return settings
+		}
+		return defaults.Bet
+	}
+}
@@ -29,6 +29,10 @@ const banner = `
 `
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "backtest" {
+		os.Exit(runBacktest(os.Args[2:]))
+	}
+
 	configDir := flag.String("config", "configs", "Path to the configuration directory")
 	port := flag.String("port", "8080", "Port for the health/analytics HTTP server")
 	logLevel := flag.String("log-level", "", "Log level: DEBUG, INFO, WARN, ERROR (overrides LOG_LEVEL env)")
@@ -123,6 +127,19 @@ func main() {
 		return all
 	})
 
+	analyticsServer.SetDropsFunc(func() []server.AccountDrops {
+		all := make([]server.AccountDrops, 0, len(miners))
+		for _, minerInstance := range miners {
+			all = append(all, server.AccountDrops{
+				Account:   minerInstance.Username(),
+				Campaigns: minerInstance.Campaigns(),
+				Streamers: minerInstance.Streamers(),
+				Watching:  minerInstance.Watching(),
+			})
+		}
+		return all
+	})
+
 	analyticsServer.SetNotifyTestFunc(func(ctx context.Context) []error {
 		var allErrs []error
 		for _, minerInstance := range miners {
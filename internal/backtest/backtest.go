@@ -0,0 +1,209 @@
+// Package backtest replays recorded prediction ledger entries through the
+// bet calculation with alternative settings to compare strategies offline.
+package backtest
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Guliveer/twitch-miner-go/internal/ledger"
+	"github.com/Guliveer/twitch-miner-go/internal/model"
+)
+
+// AllStreamers is the Result.Streamer value of a scenario's aggregate row.
+const AllStreamers = "*"
+
+// Scenario is a named set of bet settings to replay the ledger with.
+// SettingsFor returns the settings used for a given streamer, and
+// AccountBalanceFloor is the account-wide bankroll balance floor.
+type Scenario struct {
+	Name string
+	SettingsFor func(streamer string) *model.BetSettings
+	AccountBalanceFloor int
+}
+
+// Result summarises a scenario's replay, for one streamer or AllStreamers.
+type Result struct {
+	Scenario string `json:"scenario"`
+	Streamer string `json:"streamer"`
+	Predictions int `json:"predictions"`
+	Bets int `json:"bets"`
+	Skipped int `json:"skipped"`
+	Unresolved int `json:"unresolved"`
+	Wins int `json:"wins"`
+	Losses int `json:"losses"`
+	Refunds int `json:"refunds"`
+	Staked int `json:"staked"`
+	Net int `json:"net"`
+	ROI float64 `json:"roi"`
+	HitRate float64 `json:"hit_rate"`
+	MaxDrawdown int `json:"max_drawdown"`
+
+	cumulative int
+	peak       int
+}
+
+// Run replays entries, oldest first, through every scenario. For each
+// scenario it returns an AllStreamers row followed by one row per streamer
+// in alphabetical order.
+//
+// Each entry is replayed like a live bet: with its recorded outcome snapshot
+// and balance, the seconds left that the scenario's delay gives, the
+// account balance floor and, for KELLY sizing, the win rates of the entries
+// resolved before the prediction started. Payouts are estimated from the
+// snapshot pool including the simulated stake; entries whose winner is
+// unknown are counted as unresolved. Bankroll loss limits and streaks are
+// not simulated.
+func Run(entries []ledger.Entry, scenarios []Scenario) []Result {
+	sorted := make([]ledger.Entry, len(entries))
+	copy(sorted, entries)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].ResolvedAt.Before(sorted[j].ResolvedAt)
+	})
+
+	var results []Result
+	for _, sc := range scenarios {
+		total := &Result{Scenario: sc.Name, Streamer: AllStreamers}
+		perStreamer := make(map[string]*Result)
+
+		for i := range sorted {
+			entry := &sorted[i]
+			streamer := strings.ToLower(entry.Streamer)
+			r, ok := perStreamer[streamer]
+			if !ok {
+				r = &Result{Scenario: sc.Name, Streamer: streamer}
+				perStreamer[streamer] = r
+			}
+
+			settings := sc.SettingsFor(streamer)
+			if settings == nil {
+				continue
+			}
+			// Entries are sorted by resolution time, so the ones resolved
+			// before this prediction started form a prefix.
+			history := sorted[:sort.Search(i, func(j int) bool {
+				return !sorted[j].ResolvedAt.Before(entry.CreatedAt)
+			})]
+			outcome := replay(entry, settings, history, sc.AccountBalanceFloor)
+			total.add(outcome)
+			r.add(outcome)
+		}
+
+		total.finish()
+		results = append(results, *total)
+
+		names := make([]string, 0, len(perStreamer))
+		for name := range perStreamer {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			r := perStreamer[name]
+			r.finish()
+			results = append(results, *r)
+		}
+	}
+	return results
+}
+
+// replayOutcome is the simulated result of a single ledger entry.
+type replayOutcome struct {
+	kind   string // "SKIP", "UNRESOLVED", "WIN", "LOSE" or "REFUND"
+	staked int
+	gained int
+}
+
+// replay mirrors handlePredictionCreated and prepareBet in the miner.
+// history holds the entries resolved before the prediction started.
+func replay(entry *ledger.Entry, settings *model.BetSettings, history []ledger.Entry, accountFloor int) replayOutcome {
+	// Skipped entries only hold the outcomes from before anyone voted, which
+	// cannot be replayed meaningfully.
+	if entry.Result == ledger.ResultSkipped {
+		return replayOutcome{kind: "SKIP"}
+	}
+
+	outcomes := make([]model.Outcome, len(entry.Outcomes))
+	copy(outcomes, entry.Outcomes)
+	bet := model.NewBet(outcomes, settings)
+	bet.UpdateOutcomes(entry.Outcomes)
+	if window := entry.PredictionWindow; window > 0 {
+		bet.SecondsLeft = window - model.GetPredictionWindow(settings, window)
+	}
+
+	if rule, forced := settings.MatchTitleRule(entry.Title, outcomes); rule != nil {
+		if rule.Skip {
+			return replayOutcome{kind: "SKIP"}
+		}
+		bet.Settings = rule.Apply(settings)
+		if forced >= 0 {
+			bet.ForcedOutcomeID = outcomes[forced].ID
+		}
+	}
+
+	bet.AccountBalanceFloor = accountFloor
+	if bet.Settings.Sizing == model.SizingKelly {
+		bet.SetWinRates(ledger.WinRates(history, entry.Streamer, len(outcomes)))
+	}
+
+	decision := bet.Calculate(entry.Balance)
+	if skip, _ := bet.Skip(); skip || decision.Choice < 0 || decision.BelowMinimum() {
+		return replayOutcome{kind: "SKIP"}
+	}
+
+	if entry.Result == "REFUND" {
+		return replayOutcome{kind: "REFUND"}
+	}
+
+	winner := entry.WinnerIndex()
+	if winner < 0 {
+		return replayOutcome{kind: "UNRESOLVED"}
+	}
+
+	amount := decision.Amount
+	if decision.Choice != winner {
+		return replayOutcome{kind: "LOSE", staked: amount, gained: -amount}
+	}
+
+	chosen := outcomes[decision.Choice]
+	won := int(float64(amount) * float64(bet.TotalPoints+amount) / float64(chosen.TotalPoints+amount))
+	return replayOutcome{kind: "WIN", staked: amount, gained: won - amount}
+}
+
+func (r *Result) add(o replayOutcome) {
+	r.Predictions++
+	switch o.kind {
+	case "SKIP":
+		r.Skipped++
+		return
+	case "UNRESOLVED":
+		r.Unresolved++
+		return
+	case "WIN":
+		r.Wins++
+	case "LOSE":
+		r.Losses++
+	case "REFUND":
+		r.Refunds++
+	}
+
+	r.Bets++
+	r.Staked += o.staked
+	r.Net += o.gained
+
+	r.cumulative += o.gained
+	if r.cumulative > r.peak {
+		r.peak = r.cumulative
+	}
+	if drawdown := r.peak - r.cumulative; drawdown > r.MaxDrawdown {
+		r.MaxDrawdown = drawdown
+	}
+}
+
+func (r *Result) finish() {
+	if r.Staked > 0 {
+		r.ROI = float64(r.Net) / float64(r.Staked)
+	}
+	if decided := r.Wins + r.Losses; decided > 0 {
+		r.HitRate = float64(r.Wins) / float64(decided)
+	}
+}
@@ -0,0 +1,187 @@
+package backtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Guliveer/twitch-miner-go/internal/constants"
+	"github.com/Guliveer/twitch-miner-go/internal/ledger"
+	"github.com/Guliveer/twitch-miner-go/internal/model"
+)
+
+var base = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// entry returns a two-outcome ledger entry with an even pool, created one
+// minute before it resolved at base+minute. A winner of "" leaves the
+// winner unknown.
+func entry(streamer, result, winner string, balance, minute int) ledger.Entry {
+	resolved := base.Add(time.Duration(minute) * time.Minute)
+	return ledger.Entry{
+		Streamer: streamer,
+		Outcomes: []model.Outcome{
+			{ID: "a", TotalUsers: 50, TotalPoints: 1000},
+			{ID: "b", TotalUsers: 50, TotalPoints: 1000},
+		},
+		Balance:          balance,
+		Result:           result,
+		WinningOutcomeID: winner,
+		CreatedAt:        resolved.Add(-time.Minute),
+		ResolvedAt:       resolved,
+	}
+}
+
+// firstOutcome returns settings that stake 10% on the first outcome.
+func firstOutcome(string) *model.BetSettings {
+	settings := model.DefaultBetSettings()
+	settings.Strategy = model.StrategyNumber1
+	settings.Percentage = 10
+	return settings
+}
+
+func TestRun(t *testing.T) {
+	unresolved := entry("bob", "LOSE", "", 1000, 7)
+	unresolved.Outcomes = append(unresolved.Outcomes, model.Outcome{ID: "c", TotalUsers: 10, TotalPoints: 100})
+
+	// Out of order on purpose: Run sorts by resolution time.
+	entries := []ledger.Entry{
+		entry("bob", "WIN", "a", 1000, 4),
+		entry("alice", "WIN", "a", 1000, 1),   // stakes 100, wins 100*2100/1100 = 190
+		entry("alice", "LOSE", "b", 1000, 2),  // -100
+		entry("bob", "LOSE", "b", 1000, 3),    // -100
+		entry("alice", "REFUND", "", 1000, 5), // counted as a bet, no gain
+		entry("alice", ledger.ResultSkipped, "", 1000, 6),
+		unresolved,
+		entry("alice", "WIN", "a", 50, 8), // 5 points is below the minimum
+	}
+
+	results := Run(entries, []Scenario{{Name: "FIRST", SettingsFor: firstOutcome}})
+
+	want := []Result{
+		{Scenario: "FIRST", Streamer: AllStreamers, Predictions: 8, Bets: 5, Skipped: 2, Unresolved: 1,
+			Wins: 2, Losses: 2, Refunds: 1, Staked: 400, Net: -20, ROI: -0.05, HitRate: 0.5, MaxDrawdown: 200},
+		{Scenario: "FIRST", Streamer: "alice", Predictions: 5, Bets: 3, Skipped: 2,
+			Wins: 1, Losses: 1, Refunds: 1, Staked: 200, Net: -10, ROI: -0.05, HitRate: 0.5, MaxDrawdown: 100},
+		{Scenario: "FIRST", Streamer: "bob", Predictions: 3, Bets: 2, Unresolved: 1,
+			Wins: 1, Losses: 1, Staked: 200, Net: -10, ROI: -0.05, HitRate: 0.5, MaxDrawdown: 100},
+	}
+	if len(results) != len(want) {
+		t.Fatalf("got %d results, want %d: %+v", len(results), len(want), results)
+	}
+	for i := range want {
+		got := results[i]
+		got.cumulative, got.peak = 0, 0
+		if got != want[i] {
+			t.Errorf("result %d:\n got %+v\nwant %+v", i, got, want[i])
+		}
+	}
+}
+
+func TestReplayMirrorsLiveBet(t *testing.T) {
+	t.Run("account balance floor", func(t *testing.T) {
+		e := entry("alice", "WIN", "a", 1000, 1)
+		got := replay(&e, firstOutcome("alice"), nil, 950)
+		if got.staked != 50 {
+			t.Errorf("staked = %d, want 50", got.staked)
+		}
+	})
+
+	t.Run("seconds left from the scenario delay", func(t *testing.T) {
+		settings := firstOutcome("alice")
+		settings.Strategy = model.StrategyCustom
+		settings.Custom = &model.CustomStrategy{Choice: mustCompile(t, "outcome_index == 0"), Amount: mustCompile(t, "seconds_left * 10")}
+		settings.DelayMode = model.DelayModeFromEnd
+		settings.Delay = 6
+
+		e := entry("alice", "WIN", "a", 1000, 1)
+		e.PredictionWindow = 60
+		if got := replay(&e, settings, nil, 0); got.staked != 60 {
+			t.Errorf("staked = %d, want 60", got.staked)
+		}
+	})
+
+	t.Run("kelly win rates from earlier entries", func(t *testing.T) {
+		settings := firstOutcome("alice")
+		settings.Sizing = model.SizingKelly
+
+		var history []ledger.Entry
+		for i := range constants.KellyMinHistorySamples {
+			history = append(history, entry("alice", "WIN", "a", 1000, i))
+		}
+		e := entry("alice", "WIN", "a", 1000, 100)
+
+		// An even pool at even odds has no edge without history.
+		if got := replay(&e, settings, nil, 0); got.kind != "SKIP" {
+			t.Errorf("without history: kind = %s, want SKIP", got.kind)
+		}
+		if got := replay(&e, settings, history, 0); got.kind != "WIN" || got.staked <= 0 {
+			t.Errorf("with history: kind = %s, staked = %d, want a winning bet", got.kind, got.staked)
+		}
+	})
+}
+
+func TestRunUsesOnlyEarlierHistory(t *testing.T) {
+	settings := func(string) *model.BetSettings {
+		s := firstOutcome("")
+		s.Sizing = model.SizingKelly
+		return s
+	}
+
+	// The history entries resolve after the first prediction started, so it
+	// has no edge and is skipped; the last one sees all of them.
+	first := entry("alice", "WIN", "a", 1000, 0)
+	first.CreatedAt = base.Add(-time.Hour)
+	entries := []ledger.Entry{first}
+	for i := range constants.KellyMinHistorySamples {
+		entries = append(entries, entry("alice", "WIN", "a", 1000, i+1))
+	}
+	entries = append(entries, entry("alice", "WIN", "a", 1000, 1000))
+
+	total := Run(entries, []Scenario{{Name: "KELLY", SettingsFor: settings}})[0]
+	if total.Skipped < 1 || total.Bets < 1 {
+		t.Errorf("got %+v, want the first prediction skipped and later ones bet", total)
+	}
+}
+
+func mustCompile(t *testing.T, src string) *model.Expr {
+	t.Helper()
+	expr, err := model.CompileExpr(src)
+	if err != nil {
+		t.Fatalf("CompileExpr(%q): %v", src, err)
+	}
+	return expr
+}
+
+func TestWriters(t *testing.T) {
+	results := []Result{{
+		Scenario: "SMART", Streamer: AllStreamers, Predictions: 4, Bets: 3, Skipped: 1,
+		Wins: 2, Losses: 1, Staked: 300, Net: 80, ROI: 80.0 / 300, HitRate: 2.0 / 3, MaxDrawdown: 100,
+	}}
+
+	var csvOut bytes.Buffer
+	if err := WriteCSV(&csvOut, results); err != nil {
+		t.Fatalf("WriteCSV: %v", err)
+	}
+	wantCSV := "scenario,streamer,predictions,bets,skipped,unresolved,wins,losses,refunds,staked,net,roi,hit_rate,max_drawdown\n" +
+		"SMART,*,4,3,1,0,2,1,0,300,80,0.2667,0.6667,100\n"
+	if csvOut.String() != wantCSV {
+		t.Errorf("WriteCSV =\n%s\nwant\n%s", csvOut.String(), wantCSV)
+	}
+
+	var jsonOut bytes.Buffer
+	if err := WriteJSON(&jsonOut, results); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+	var decoded []Result
+	if err := json.Unmarshal(jsonOut.Bytes(), &decoded); err != nil {
+		t.Fatalf("decoding WriteJSON output: %v", err)
+	}
+	if len(decoded) != 1 || decoded[0] != results[0] {
+		t.Errorf("WriteJSON round trip = %+v, want %+v", decoded, results)
+	}
+	if !strings.Contains(jsonOut.String(), `"max_drawdown": 100`) {
+		t.Errorf("WriteJSON output is not indented:\n%s", jsonOut.String())
+	}
+}
@@ -0,0 +1,51 @@
+package backtest
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+)
+
+// WriteJSON writes results as an indented JSON array.
+func WriteJSON(w io.Writer, results []Result) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(results)
+}
+
+// WriteCSV writes results as CSV with a header row. Ratios have four
+// decimals.
+func WriteCSV(w io.Writer, results []Result) error {
+	cw := csv.NewWriter(w)
+	header := []string{
+		"scenario", "streamer", "predictions", "bets", "skipped", "unresolved",
+		"wins", "losses", "refunds", "staked", "net", "roi", "hit_rate", "max_drawdown",
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, r := range results {
+		row := []string{
+			r.Scenario,
+			r.Streamer,
+			strconv.Itoa(r.Predictions),
+			strconv.Itoa(r.Bets),
+			strconv.Itoa(r.Skipped),
+			strconv.Itoa(r.Unresolved),
+			strconv.Itoa(r.Wins),
+			strconv.Itoa(r.Losses),
+			strconv.Itoa(r.Refunds),
+			strconv.Itoa(r.Staked),
+			strconv.Itoa(r.Net),
+			strconv.FormatFloat(r.ROI, 'f', 4, 64),
+			strconv.FormatFloat(r.HitRate, 'f', 4, 64),
+			strconv.Itoa(r.MaxDrawdown),
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -1,8 +1,11 @@
 package config
 
 import (
+	"fmt"
+	"regexp"
 	"time"
 
+	"github.com/Guliveer/twitch-miner-go/internal/constants"
 	"github.com/Guliveer/twitch-miner-go/internal/model"
 )
 
@@ -29,6 +32,12 @@ type AccountConfig struct {
 
 	Followers FollowersConfig `yaml:"followers"`
 
+	FollowRaidTarget FollowRaidTargetConfig `yaml:"follow_raid_target"`
+
+	Bankroll BankrollConfig `yaml:"bankroll"`
+
+	Drops DropsConfig `yaml:"drops"`
+
 	Notifications NotificationsConfig `yaml:"notifications"`
 }
 
@@ -49,6 +58,7 @@ type CategoryWatcherConfig struct {
 	Enabled bool `yaml:"enabled"`
 	PollInterval time.Duration `yaml:"poll_interval"`
 	DropsOnly bool `yaml:"drops_only"`
+	AutoDropsCategories bool `yaml:"auto_drops_categories"` // Also watch the games of active drop campaigns
 	Categories []CategoryConfig `yaml:"categories"`
 }
 
@@ -56,6 +66,100 @@ type CategoryWatcherConfig struct {
 type CategoryConfig struct {
 	Slug string `yaml:"slug"`
 	DropsOnly *bool `yaml:"drops_only,omitempty"`
+	Slots int `yaml:"slots,omitempty"` // Streamers watched at once, default 1
+	PreferLanguages []string `yaml:"prefer_languages,omitempty"` // Ranked ahead of other languages
+	Languages []string `yaml:"languages,omitempty"` // Only streams in these languages
+	MinViewers int `yaml:"min_viewers,omitempty"`
+	MaxViewers int `yaml:"max_viewers,omitempty"`
+	RequiredTags []string `yaml:"required_tags,omitempty"`
+	ExcludedTags []string `yaml:"excluded_tags,omitempty"`
+	TitleInclude string `yaml:"title_include,omitempty"` // Regular expression
+	TitleExclude string `yaml:"title_exclude,omitempty"` // Regular expression
+	ExcludeMature bool `yaml:"exclude_mature,omitempty"`
+}
+
+// ToCategoryFilter converts the stream filters of a CategoryConfig to a
+// model.CategoryFilter. It returns nil when no filter is set.
+func (cc *CategoryConfig) ToCategoryFilter() (*model.CategoryFilter, error) {
+	filter := &model.CategoryFilter{
+		Languages:     cc.Languages,
+		MinViewers:    cc.MinViewers,
+		MaxViewers:    cc.MaxViewers,
+		RequiredTags:  cc.RequiredTags,
+		ExcludedTags:  cc.ExcludedTags,
+		ExcludeMature: cc.ExcludeMature,
+	}
+	if cc.TitleInclude != "" {
+		re, err := regexp.Compile(cc.TitleInclude)
+		if err != nil {
+			return nil, fmt.Errorf("title_include: invalid pattern %q: %w", cc.TitleInclude, err)
+		}
+		filter.TitleInclude = re
+	}
+	if cc.TitleExclude != "" {
+		re, err := regexp.Compile(cc.TitleExclude)
+		if err != nil {
+			return nil, fmt.Errorf("title_exclude: invalid pattern %q: %w", cc.TitleExclude, err)
+		}
+		filter.TitleExclude = re
+	}
+	if len(filter.Languages) == 0 && filter.MinViewers == 0 && filter.MaxViewers == 0 &&
+		len(filter.RequiredTags) == 0 && len(filter.ExcludedTags) == 0 &&
+		filter.TitleInclude == nil && filter.TitleExclude == nil && !filter.ExcludeMature {
+		return nil, nil
+	}
+	return filter, nil
+}
+
+// DropsConfig holds account-level filters for drop campaigns.
+type DropsConfig struct {
+	IncludeGames []string `yaml:"include_games,omitempty"`
+	ExcludeGames []string `yaml:"exclude_games,omitempty"`
+	IncludeBenefits []string `yaml:"include_benefits,omitempty"`
+	ExcludeBenefits []string `yaml:"exclude_benefits,omitempty"`
+	SkipAccountLinking bool `yaml:"skip_account_linking,omitempty"`
+	Watcher DropsWatcherConfig `yaml:"watcher"`
+}
+
+// DropsWatcherConfig holds settings for the drops watcher, which adds a
+// streamer for every unfinished drop campaign.
+type DropsWatcherConfig struct {
+	Enabled bool `yaml:"enabled"`
+	PollInterval time.Duration `yaml:"poll_interval"`
+}
+
+// FollowRaidTargetConfig holds settings for following raid targets. When
+// enabled, the target of a joined raid is watched as a temporary streamer
+// for up to TTL, using Settings resolved on top of streamer_defaults.
+type FollowRaidTargetConfig struct {
+	Enabled bool `yaml:"enabled"`
+	TTL time.Duration `yaml:"ttl"`
+	Settings *StreamerSettingsConfig `yaml:"settings,omitempty"`
+}
+
+// ToDropsFilter converts a DropsConfig to a model.DropsFilter.
+// Patterns are validated at load time; an invalid pattern returns an error.
+func (dc *DropsConfig) ToDropsFilter() (model.DropsFilter, error) {
+	filter := model.DropsFilter{
+		IncludeGames:       dc.IncludeGames,
+		ExcludeGames:       dc.ExcludeGames,
+		SkipAccountLinking: dc.SkipAccountLinking,
+	}
+	for _, pattern := range dc.IncludeBenefits {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return filter, fmt.Errorf("drops.include_benefits: invalid pattern %q: %w", pattern, err)
+		}
+		filter.IncludeBenefits = append(filter.IncludeBenefits, re)
+	}
+	for _, pattern := range dc.ExcludeBenefits {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return filter, fmt.Errorf("drops.exclude_benefits: invalid pattern %q: %w", pattern, err)
+		}
+		filter.ExcludeBenefits = append(filter.ExcludeBenefits, re)
+	}
+	return filter, nil
 }
 
 // StreamerSettingsConfig is the YAML representation of per-streamer settings.
@@ -66,8 +170,119 @@ type StreamerSettingsConfig struct {
 	ClaimMoments *bool `yaml:"claim_moments,omitempty"`
 	WatchStreak *bool `yaml:"watch_streak,omitempty"`
 	CommunityGoals *bool `yaml:"community_goals,omitempty"`
+	HypeTrain *bool `yaml:"hype_train,omitempty"`
+	Polls *bool `yaml:"polls,omitempty"`
 	Chat string `yaml:"chat,omitempty"`
 	Bet *BetSettingsConfig `yaml:"bet,omitempty"`
+	Redeem []RedeemRuleConfig `yaml:"redeem,omitempty"`
+	Goals *GoalPolicyConfig `yaml:"goals,omitempty"`
+	Raid *RaidFilterConfig `yaml:"raid,omitempty"`
+}
+
+// RaidFilterConfig is the YAML representation of a streamer's raid filter.
+// A block set on a streamer replaces the one from streamer_defaults.
+type RaidFilterConfig struct {
+	IncludeTargets []string `yaml:"include_targets,omitempty"`
+	ExcludeTargets []string `yaml:"exclude_targets,omitempty"`
+	Categories []string `yaml:"categories,omitempty"`
+	MinViewers int `yaml:"min_viewers,omitempty"`
+	MaxViewers int `yaml:"max_viewers,omitempty"`
+}
+
+// ToRaidFilter converts a RaidFilterConfig to a model.RaidFilter.
+func (rfc *RaidFilterConfig) ToRaidFilter() *model.RaidFilter {
+	return &model.RaidFilter{
+		IncludeTargets: rfc.IncludeTargets,
+		ExcludeTargets: rfc.ExcludeTargets,
+		Categories:     rfc.Categories,
+		MinViewers:     rfc.MinViewers,
+		MaxViewers:     rfc.MaxViewers,
+	}
+}
+
+// GoalPolicyConfig is the YAML representation of a community goal
+// contribution policy. Titles are regular expressions.
+type GoalPolicyConfig struct {
+	MaxBalancePercentage *int `yaml:"max_balance_percentage,omitempty"`
+	MaxPerGoal *int `yaml:"max_per_goal,omitempty"`
+	MaxPerStream *int `yaml:"max_per_stream,omitempty"`
+	Titles []string `yaml:"titles,omitempty"`
+	Reserve *int `yaml:"reserve,omitempty"`
+	WithinPercentage *int `yaml:"within_percentage,omitempty"`
+}
+
+// ToGoalPolicy converts a GoalPolicyConfig to a model.GoalPolicy, using
+// defaults (which may be nil) for any unset fields. Patterns are validated
+// at load time; an invalid pattern returns an error.
+func (gpc *GoalPolicyConfig) ToGoalPolicy(defaults *model.GoalPolicy) (*model.GoalPolicy, error) {
+	policy := model.GoalPolicy{}
+	if defaults != nil {
+		policy = *defaults
+	}
+
+	if gpc.MaxBalancePercentage != nil {
+		policy.MaxBalancePercentage = *gpc.MaxBalancePercentage
+	}
+	if gpc.MaxPerGoal != nil {
+		policy.MaxPerGoal = *gpc.MaxPerGoal
+	}
+	if gpc.MaxPerStream != nil {
+		policy.MaxPerStream = *gpc.MaxPerStream
+	}
+	if gpc.Reserve != nil {
+		policy.Reserve = *gpc.Reserve
+	}
+	if gpc.WithinPercentage != nil {
+		policy.WithinPercentage = *gpc.WithinPercentage
+	}
+	if gpc.Titles != nil {
+		policy.Titles = make([]*regexp.Regexp, 0, len(gpc.Titles))
+		for _, pattern := range gpc.Titles {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("goals.titles: invalid pattern %q: %w", pattern, err)
+			}
+			policy.Titles = append(policy.Titles, re)
+		}
+	}
+	return &policy, nil
+}
+
+// RedeemRuleConfig is the YAML representation of a reward redemption rule.
+// Reward is a regular expression matched against custom reward titles.
+type RedeemRuleConfig struct {
+	Name string `yaml:"name,omitempty"`
+	Reward string `yaml:"reward"`
+	KeepBalance int `yaml:"keep_balance,omitempty"`
+	MaxCost int `yaml:"max_cost,omitempty"`
+	MaxPerStream *int `yaml:"max_per_stream,omitempty"`
+	Text string `yaml:"text,omitempty"`
+	DryRun bool `yaml:"dry_run,omitempty"`
+}
+
+// ToRedeemRule converts a RedeemRuleConfig to a model.RedeemRule.
+// Patterns are validated at load time; an invalid pattern returns an error.
+func (rrc *RedeemRuleConfig) ToRedeemRule() (model.RedeemRule, error) {
+	rule := model.RedeemRule{
+		Name:         rrc.Name,
+		KeepBalance:  rrc.KeepBalance,
+		MaxCost:      rrc.MaxCost,
+		MaxPerStream: 1,
+		Text:         rrc.Text,
+		DryRun:       rrc.DryRun,
+	}
+	if rule.Name == "" {
+		rule.Name = rrc.Reward
+	}
+	if rrc.MaxPerStream != nil {
+		rule.MaxPerStream = *rrc.MaxPerStream
+	}
+	re, err := regexp.Compile(rrc.Reward)
+	if err != nil {
+		return rule, fmt.Errorf("redeem rule %q: invalid reward pattern: %w", rule.Name, err)
+	}
+	rule.Reward = re
+	return rule, nil
 }
 
 // BetSettingsConfig is the YAML representation of bet settings.
@@ -80,14 +295,157 @@ type BetSettingsConfig struct {
 	StealthMode *bool `yaml:"stealth_mode,omitempty"`
 	Delay *float64 `yaml:"delay,omitempty"`
 	DelayMode string `yaml:"delay_mode,omitempty"`
+	Sizing string `yaml:"sizing,omitempty"`
+	KellyFraction *float64 `yaml:"kelly_fraction,omitempty"`
 	FilterCondition *FilterConditionConfig `yaml:"filter_condition,omitempty"`
+	FilterConditions []FilterConditionConfig `yaml:"filter_conditions,omitempty"`
+	FilterMatch string `yaml:"filter_match,omitempty"`
+	TitleRules []TitleRuleConfig `yaml:"title_rules,omitempty"`
+	Bankroll *BankrollConfig `yaml:"bankroll,omitempty"`
+	Custom *CustomStrategyConfig `yaml:"custom,omitempty"`
+}
+
+// CustomStrategyConfig is the YAML representation of the CUSTOM strategy.
+// Choice scores each outcome (highest wins) and the optional Amount gives
+// the stake; both are expressions validated at load time.
+type CustomStrategyConfig struct {
+	Choice string `yaml:"choice"`
+	Amount string `yaml:"amount,omitempty"`
+}
+
+// ToCustomStrategy compiles a CustomStrategyConfig to a model.CustomStrategy.
+func (csc *CustomStrategyConfig) ToCustomStrategy() (*model.CustomStrategy, error) {
+	custom := &model.CustomStrategy{}
+	if csc.Choice != "" {
+		expr, err := model.CompileExpr(csc.Choice)
+		if err != nil {
+			return nil, fmt.Errorf("custom.choice: %w", err)
+		}
+		custom.Choice = expr
+	}
+	if csc.Amount != "" {
+		expr, err := model.CompileExpr(csc.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("custom.amount: %w", err)
+		}
+		custom.Amount = expr
+	}
+	return custom, nil
+}
+
+// BankrollConfig is the YAML representation of bankroll protection rules.
+// At the account level, loss limits and the losing streak count bets on all
+// streamers; max_bets_per_stream and balance_floor only apply per streamer.
+type BankrollConfig struct {
+	DailyLossLimit *int `yaml:"daily_loss_limit,omitempty"`
+	WeeklyLossLimit *int `yaml:"weekly_loss_limit,omitempty"`
+	MaxBetsPerStream *int `yaml:"max_bets_per_stream,omitempty"`
+	LosingStreak *int `yaml:"losing_streak,omitempty"`
+	Cooldown *time.Duration `yaml:"cooldown,omitempty"`
+	BalanceFloor *int `yaml:"balance_floor,omitempty"`
+}
+
+// ToBankrollSettings converts a BankrollConfig to model.BankrollSettings,
+// using defaults for any unset fields.
+func (bc *BankrollConfig) ToBankrollSettings(defaults model.BankrollSettings) model.BankrollSettings {
+	settings := defaults
+
+	if bc == nil {
+		return settings
+	}
+
+	if bc.DailyLossLimit != nil {
+		settings.DailyLossLimit = *bc.DailyLossLimit
+	}
+	if bc.WeeklyLossLimit != nil {
+		settings.WeeklyLossLimit = *bc.WeeklyLossLimit
+	}
+	if bc.MaxBetsPerStream != nil {
+		settings.MaxBetsPerStream = *bc.MaxBetsPerStream
+	}
+	if bc.LosingStreak != nil {
+		settings.LosingStreak = *bc.LosingStreak
+	}
+	if bc.Cooldown != nil {
+		settings.Cooldown = *bc.Cooldown
+	}
+	if bc.BalanceFloor != nil {
+		settings.BalanceFloor = *bc.BalanceFloor
+	}
+	if settings.LosingStreak > 0 && settings.Cooldown == 0 {
+		settings.Cooldown = constants.DefaultLosingStreakCooldown
+	}
+
+	return settings
+}
+
+// TitleRuleConfig is the YAML representation of a prediction title rule.
+// Title and Outcome are regular expressions matched against the prediction
+// title and the outcome titles respectively.
+type TitleRuleConfig struct {
+	Name string `yaml:"name,omitempty"`
+	Title string `yaml:"title,omitempty"`
+	Outcome string `yaml:"outcome,omitempty"`
+	Skip bool `yaml:"skip,omitempty"`
+	Strategy string `yaml:"strategy,omitempty"`
+	Percentage *int `yaml:"percentage,omitempty"`
+}
+
+// ToTitleRule converts a TitleRuleConfig to a model.TitleRule.
+// Patterns are validated at load time; an invalid pattern returns an error.
+func (trc *TitleRuleConfig) ToTitleRule() (model.TitleRule, error) {
+	rule := model.TitleRule{
+		Name:       trc.Name,
+		Skip:       trc.Skip,
+		Percentage: trc.Percentage,
+	}
+	if rule.Name == "" {
+		rule.Name = trc.Title
+		if rule.Name == "" {
+			rule.Name = trc.Outcome
+		}
+	}
+	if trc.Title != "" {
+		re, err := regexp.Compile(trc.Title)
+		if err != nil {
+			return rule, fmt.Errorf("title rule %q: invalid title pattern: %w", rule.Name, err)
+		}
+		rule.Title = re
+	}
+	if trc.Outcome != "" {
+		re, err := regexp.Compile(trc.Outcome)
+		if err != nil {
+			return rule, fmt.Errorf("title rule %q: invalid outcome pattern: %w", rule.Name, err)
+		}
+		rule.Outcome = re
+	}
+	if trc.Strategy != "" {
+		if !model.IsValidStrategy(trc.Strategy) {
+			return rule, fmt.Errorf("title rule %q: unknown strategy %q", rule.Name, trc.Strategy)
+		}
+		strategy := model.ParseStrategy(trc.Strategy)
+		rule.Strategy = &strategy
+	}
+	return rule, nil
 }
 
 // FilterConditionConfig is the YAML representation of a filter condition.
+// Max is the inclusive upper bound for the BETWEEN operator.
 type FilterConditionConfig struct {
 	By string `yaml:"by"`
 	Where string `yaml:"where"`
 	Value float64 `yaml:"value"`
+	Max float64 `yaml:"max,omitempty"`
+}
+
+// ToFilterCondition converts a FilterConditionConfig to a model.FilterCondition.
+func (fcc *FilterConditionConfig) ToFilterCondition() model.FilterCondition {
+	return model.FilterCondition{
+		By:    model.OutcomeKey(fcc.By),
+		Where: model.ParseCondition(fcc.Where),
+		Value: fcc.Value,
+		Max:   fcc.Max,
+	}
 }
 
 // StreamerConfig holds per-streamer configuration from YAML.
@@ -100,6 +458,7 @@ type StreamerConfig struct {
 type FollowersConfig struct {
 	Enabled bool `yaml:"enabled"`
 	Order string `yaml:"order"`
+	RefreshInterval time.Duration `yaml:"refresh_interval"` // How often follows are re-read
 }
 
 // NotificationsConfig holds all notification provider configurations.
@@ -188,12 +547,36 @@ func (ssc *StreamerSettingsConfig) ToStreamerSettings(defaults *model.StreamerSe
 	if ssc.CommunityGoals != nil {
 		settings.CommunityGoalsEnabled = *ssc.CommunityGoals
 	}
+	if ssc.HypeTrain != nil {
+		settings.HypeTrain = *ssc.HypeTrain
+	}
+	if ssc.Polls != nil {
+		settings.Polls = *ssc.Polls
+	}
 	if ssc.Chat != "" {
 		settings.Chat = model.ParseChatPresence(ssc.Chat)
 	}
 	if ssc.Bet != nil {
 		settings.Bet = ssc.Bet.ToBetSettings(defaults.Bet)
 	}
+	if ssc.Goals != nil {
+		if policy, err := ssc.Goals.ToGoalPolicy(defaults.GoalPolicy); err == nil { // errors rejected by Validate
+			settings.GoalPolicy = policy
+		}
+	}
+	if ssc.Raid != nil {
+		settings.RaidFilter = ssc.Raid.ToRaidFilter()
+	}
+	if ssc.Redeem != nil {
+		settings.RedeemRules = make([]model.RedeemRule, 0, len(ssc.Redeem))
+		for i := range ssc.Redeem {
+			rule, err := ssc.Redeem[i].ToRedeemRule()
+			if err != nil {
+				continue // rejected by Validate at load time
+			}
+			settings.RedeemRules = append(settings.RedeemRules, rule)
+		}
+	}
 
 	return &settings
 }
@@ -231,11 +614,41 @@ func (bsc *BetSettingsConfig) ToBetSettings(defaults *model.BetSettings) *model.
 	if bsc.DelayMode != "" {
 		betSettings.DelayMode = model.ParseDelayMode(bsc.DelayMode)
 	}
+	if bsc.Sizing != "" {
+		betSettings.Sizing = model.ParseSizingMode(bsc.Sizing)
+	}
+	if bsc.KellyFraction != nil {
+		betSettings.KellyFraction = *bsc.KellyFraction
+	}
 	if bsc.FilterCondition != nil {
-		betSettings.FilterCondition = &model.FilterCondition{
-			By:    model.OutcomeKey(bsc.FilterCondition.By),
-			Where: model.ParseCondition(bsc.FilterCondition.Where),
-			Value: bsc.FilterCondition.Value,
+		fc := bsc.FilterCondition.ToFilterCondition()
+		betSettings.FilterCondition = &fc
+	}
+	if len(bsc.FilterConditions) > 0 {
+		betSettings.FilterConditions = make([]model.FilterCondition, 0, len(bsc.FilterConditions))
+		for i := range bsc.FilterConditions {
+			betSettings.FilterConditions = append(betSettings.FilterConditions, bsc.FilterConditions[i].ToFilterCondition())
+		}
+	}
+	if bsc.FilterMatch != "" {
+		betSettings.FilterMatch = model.ParseFilterMatch(bsc.FilterMatch)
+	}
+	if bsc.Custom != nil {
+		if custom, err := bsc.Custom.ToCustomStrategy(); err == nil { // errors rejected by Validate
+			betSettings.Custom = custom
+		}
+	}
+	if bsc.Bankroll != nil {
+		betSettings.Bankroll = bsc.Bankroll.ToBankrollSettings(defaults.Bankroll)
+	}
+	if len(bsc.TitleRules) > 0 {
+		betSettings.TitleRules = make([]model.TitleRule, 0, len(bsc.TitleRules))
+		for i := range bsc.TitleRules {
+			rule, err := bsc.TitleRules[i].ToTitleRule()
+			if err != nil {
+				continue // rejected by Validate at load time
+			}
+			betSettings.TitleRules = append(betSettings.TitleRules, rule)
 		}
 	}
 
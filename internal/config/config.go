@@ -11,6 +11,9 @@ import (
 	"time"
 
 	"gopkg.in/yaml.v3"
+
+	"github.com/Guliveer/twitch-miner-go/internal/constants"
+	"github.com/Guliveer/twitch-miner-go/internal/model"
 )
 
 // DefaultConfigDir is the default directory for account configuration files.
@@ -85,9 +88,17 @@ func applyDefaults(cfg *AccountConfig) {
 		cfg.CategoryWatcher.PollInterval = 120 * time.Second
 	}
 
+	if cfg.FollowRaidTarget.TTL == 0 {
+		cfg.FollowRaidTarget.TTL = constants.DefaultRaidTargetTTL
+	}
+
 	if cfg.Followers.Order == "" {
 		cfg.Followers.Order = "ASC"
 	}
+
+	if cfg.Followers.RefreshInterval == 0 {
+		cfg.Followers.RefreshInterval = constants.DefaultFollowersRefreshInterval
+	}
 }
 
 // getEnv looks up an environment variable with a per-account suffix.
@@ -158,14 +169,103 @@ func Validate(cfg *AccountConfig) error {
 		return fmt.Errorf("username is required")
 	}
 
-	if len(cfg.Streamers) == 0 && !cfg.Followers.Enabled && !cfg.CategoryWatcher.Enabled {
-		return fmt.Errorf("account %s: at least one of streamers, followers, or category_watcher must be configured", cfg.Username)
+	if len(cfg.Streamers) == 0 && !cfg.Followers.Enabled && !cfg.CategoryWatcher.Enabled && !cfg.Drops.Watcher.Enabled {
+		return fmt.Errorf("account %s: at least one of streamers, followers, category_watcher or drops.watcher must be configured", cfg.Username)
+	}
+
+	if _, err := cfg.Drops.ToDropsFilter(); err != nil {
+		return fmt.Errorf("account %s: %w", cfg.Username, err)
+	}
+
+	if err := validateBankroll(&cfg.Bankroll); err != nil {
+		return fmt.Errorf("account %s: %w", cfg.Username, err)
+	}
+
+	if err := validateBet(cfg.StreamerDefaults.Bet); err != nil {
+		return fmt.Errorf("account %s: streamer_defaults: %w", cfg.Username, err)
+	}
+	if err := validateRedeem(cfg.StreamerDefaults.Redeem); err != nil {
+		return fmt.Errorf("account %s: streamer_defaults: %w", cfg.Username, err)
+	}
+	if err := validateGoals(cfg.StreamerDefaults.Goals); err != nil {
+		return fmt.Errorf("account %s: streamer_defaults: %w", cfg.Username, err)
+	}
+	if err := validateRaid(cfg.StreamerDefaults.Raid); err != nil {
+		return fmt.Errorf("account %s: streamer_defaults: %w", cfg.Username, err)
+	}
+	defaultBet := cfg.StreamerDefaults.Bet.ToBetSettings(model.DefaultBetSettings())
+	if err := validateCustomStrategy(defaultBet); err != nil {
+		return fmt.Errorf("account %s: streamer_defaults: %w", cfg.Username, err)
 	}
 
 	for i, streamerCfg := range cfg.Streamers {
 		if streamerCfg.Username == "" {
 			return fmt.Errorf("account %s: streamer at index %d has empty username", cfg.Username, i)
 		}
+		if streamerCfg.Settings != nil {
+			if err := validateBet(streamerCfg.Settings.Bet); err != nil {
+				return fmt.Errorf("account %s: streamer %s: %w", cfg.Username, streamerCfg.Username, err)
+			}
+			if err := validateCustomStrategy(streamerCfg.Settings.Bet.ToBetSettings(defaultBet)); err != nil {
+				return fmt.Errorf("account %s: streamer %s: %w", cfg.Username, streamerCfg.Username, err)
+			}
+			if err := validateRedeem(streamerCfg.Settings.Redeem); err != nil {
+				return fmt.Errorf("account %s: streamer %s: %w", cfg.Username, streamerCfg.Username, err)
+			}
+			if err := validateGoals(streamerCfg.Settings.Goals); err != nil {
+				return fmt.Errorf("account %s: streamer %s: %w", cfg.Username, streamerCfg.Username, err)
+			}
+			if err := validateRaid(streamerCfg.Settings.Raid); err != nil {
+				return fmt.Errorf("account %s: streamer %s: %w", cfg.Username, streamerCfg.Username, err)
+			}
+		}
+	}
+
+	if cfg.Followers.Enabled && cfg.Followers.RefreshInterval < constants.MinFollowersRefreshInterval {
+		return fmt.Errorf("account %s: followers: refresh_interval must be at least %s", cfg.Username, constants.MinFollowersRefreshInterval)
+	}
+
+	if cfg.CategoryWatcher.Enabled && cfg.CategoryWatcher.AutoDropsCategories && cfg.Drops.Watcher.Enabled {
+		return fmt.Errorf("account %s: category_watcher.auto_drops_categories and drops.watcher both watch drop campaigns, enable only one", cfg.Username)
+	}
+
+	for _, category := range cfg.CategoryWatcher.Categories {
+		if category.Slug == "" {
+			return fmt.Errorf("account %s: category_watcher: category with empty slug", cfg.Username)
+		}
+		if category.Slots < 0 || category.Slots > constants.CategoryWatcherMaxSlots {
+			return fmt.Errorf("account %s: category_watcher: %s: slots must be between 0 and %d", cfg.Username, category.Slug, constants.CategoryWatcherMaxSlots)
+		}
+		if category.MinViewers < 0 || category.MaxViewers < 0 {
+			return fmt.Errorf("account %s: category_watcher: %s: min_viewers and max_viewers must not be negative", cfg.Username, category.Slug)
+		}
+		if category.MaxViewers > 0 && category.MinViewers > category.MaxViewers {
+			return fmt.Errorf("account %s: category_watcher: %s: min_viewers must not exceed max_viewers", cfg.Username, category.Slug)
+		}
+		if _, err := category.ToCategoryFilter(); err != nil {
+			return fmt.Errorf("account %s: category_watcher: %s: %w", cfg.Username, category.Slug, err)
+		}
+	}
+
+	if cfg.FollowRaidTarget.TTL < 0 {
+		return fmt.Errorf("account %s: follow_raid_target: ttl must not be negative", cfg.Username)
+	}
+	if raidSettings := cfg.FollowRaidTarget.Settings; raidSettings != nil {
+		if err := validateBet(raidSettings.Bet); err != nil {
+			return fmt.Errorf("account %s: follow_raid_target.settings: %w", cfg.Username, err)
+		}
+		if err := validateCustomStrategy(raidSettings.Bet.ToBetSettings(defaultBet)); err != nil {
+			return fmt.Errorf("account %s: follow_raid_target.settings: %w", cfg.Username, err)
+		}
+		if err := validateRedeem(raidSettings.Redeem); err != nil {
+			return fmt.Errorf("account %s: follow_raid_target.settings: %w", cfg.Username, err)
+		}
+		if err := validateGoals(raidSettings.Goals); err != nil {
+			return fmt.Errorf("account %s: follow_raid_target.settings: %w", cfg.Username, err)
+		}
+		if err := validateRaid(raidSettings.Raid); err != nil {
+			return fmt.Errorf("account %s: follow_raid_target.settings: %w", cfg.Username, err)
+		}
 	}
 
 	if cfg.Notifications.Telegram != nil && cfg.Notifications.Telegram.Enabled {
@@ -183,3 +283,186 @@ func Validate(cfg *AccountConfig) error {
 
 	return nil
 }
+
+// validateBet checks the bet settings block of a streamer configuration.
+func validateBet(bsc *BetSettingsConfig) error {
+	if bsc == nil {
+		return nil
+	}
+	if bsc.KellyFraction != nil && (*bsc.KellyFraction <= 0 || *bsc.KellyFraction > 1) {
+		return fmt.Errorf("bet.kelly_fraction must be in (0, 1], got %v", *bsc.KellyFraction)
+	}
+	if bsc.Sizing != "" && bsc.Sizing != "PERCENTAGE" && bsc.Sizing != "KELLY" {
+		return fmt.Errorf("bet.sizing must be PERCENTAGE or KELLY, got %q", bsc.Sizing)
+	}
+	if bsc.FilterMatch != "" && bsc.FilterMatch != "ALL" && bsc.FilterMatch != "ANY" {
+		return fmt.Errorf("bet.filter_match must be ALL or ANY, got %q", bsc.FilterMatch)
+	}
+
+	conditions := bsc.FilterConditions
+	if bsc.FilterCondition != nil {
+		conditions = append([]FilterConditionConfig{*bsc.FilterCondition}, conditions...)
+	}
+	for _, fc := range conditions {
+		if fc.By == "" {
+			return fmt.Errorf("bet filter condition has empty 'by'")
+		}
+		if !model.OutcomeKey(fc.By).Valid() {
+			return fmt.Errorf("bet filter condition has unknown 'by' %q", fc.By)
+		}
+		switch fc.Where {
+		case "GT", "LT", "GTE", "LTE", "BETWEEN":
+		default:
+			return fmt.Errorf("bet filter condition on %s: 'where' must be GT, LT, GTE, LTE or BETWEEN, got %q", fc.By, fc.Where)
+		}
+		if fc.Where == "BETWEEN" && fc.Max < fc.Value {
+			return fmt.Errorf("bet filter condition on %s: BETWEEN max %v is below value %v", fc.By, fc.Max, fc.Value)
+		}
+	}
+
+	if bsc.Custom != nil {
+		if _, err := bsc.Custom.ToCustomStrategy(); err != nil {
+			return fmt.Errorf("bet.%w", err)
+		}
+	}
+	if err := validateBankroll(bsc.Bankroll); err != nil {
+		return fmt.Errorf("bet.%w", err)
+	}
+
+	for i := range bsc.TitleRules {
+		rule := &bsc.TitleRules[i]
+		if rule.Title == "" && rule.Outcome == "" {
+			return fmt.Errorf("bet.title_rules[%d]: title or outcome pattern is required", i)
+		}
+		if _, err := rule.ToTitleRule(); err != nil {
+			return fmt.Errorf("bet.title_rules[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// validateBankroll checks a bankroll protection block. Every limit is a
+// count, amount or duration where 0 means disabled, so none may be negative.
+func validateBankroll(bc *BankrollConfig) error {
+	if bc == nil {
+		return nil
+	}
+	limits := []struct {
+		name  string
+		value *int
+	}{
+		{"daily_loss_limit", bc.DailyLossLimit},
+		{"weekly_loss_limit", bc.WeeklyLossLimit},
+		{"max_bets_per_stream", bc.MaxBetsPerStream},
+		{"losing_streak", bc.LosingStreak},
+		{"balance_floor", bc.BalanceFloor},
+	}
+	for _, limit := range limits {
+		if limit.value != nil && *limit.value < 0 {
+			return fmt.Errorf("bankroll.%s must not be negative, got %d", limit.name, *limit.value)
+		}
+	}
+	if bc.Cooldown != nil && *bc.Cooldown < 0 {
+		return fmt.Errorf("bankroll.cooldown must not be negative, got %s", *bc.Cooldown)
+	}
+	return nil
+}
+
+// validateRedeem checks the reward redemption rules of a streamer configuration.
+func validateRedeem(rules []RedeemRuleConfig) error {
+	for i := range rules {
+		rule := &rules[i]
+		if rule.Reward == "" {
+			return fmt.Errorf("redeem[%d]: reward pattern is required", i)
+		}
+		if rule.KeepBalance < 0 {
+			return fmt.Errorf("redeem[%d]: keep_balance must not be negative, got %d", i, rule.KeepBalance)
+		}
+		if rule.MaxCost < 0 {
+			return fmt.Errorf("redeem[%d]: max_cost must not be negative, got %d", i, rule.MaxCost)
+		}
+		if rule.MaxPerStream != nil && *rule.MaxPerStream < 1 {
+			return fmt.Errorf("redeem[%d]: max_per_stream must be at least 1, got %d", i, *rule.MaxPerStream)
+		}
+		if _, err := rule.ToRedeemRule(); err != nil {
+			return fmt.Errorf("redeem[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// validateGoals checks the community goal policy of a streamer configuration.
+func validateGoals(gpc *GoalPolicyConfig) error {
+	if gpc == nil {
+		return nil
+	}
+	percentages := map[string]*int{
+		"max_balance_percentage": gpc.MaxBalancePercentage,
+		"within_percentage":      gpc.WithinPercentage,
+	}
+	for key, value := range percentages {
+		if value != nil && (*value < 0 || *value > 100) {
+			return fmt.Errorf("goals.%s must be in [0, 100], got %d", key, *value)
+		}
+	}
+	limits := map[string]*int{
+		"max_per_goal":   gpc.MaxPerGoal,
+		"max_per_stream": gpc.MaxPerStream,
+		"reserve":        gpc.Reserve,
+	}
+	for key, value := range limits {
+		if value != nil && *value < 0 {
+			return fmt.Errorf("goals.%s must not be negative, got %d", key, *value)
+		}
+	}
+	if _, err := gpc.ToGoalPolicy(nil); err != nil {
+		return err
+	}
+	return nil
+}
+
+// validateRaid checks the raid filter of a streamer configuration.
+func validateRaid(rfc *RaidFilterConfig) error {
+	if rfc == nil {
+		return nil
+	}
+	if rfc.MinViewers < 0 {
+		return fmt.Errorf("raid.min_viewers must not be negative, got %d", rfc.MinViewers)
+	}
+	if rfc.MaxViewers < 0 {
+		return fmt.Errorf("raid.max_viewers must not be negative, got %d", rfc.MaxViewers)
+	}
+	if rfc.MaxViewers > 0 && rfc.MaxViewers < rfc.MinViewers {
+		return fmt.Errorf("raid.max_viewers %d is below min_viewers %d", rfc.MaxViewers, rfc.MinViewers)
+	}
+	return nil
+}
+
+// ResolveBetSettings validates a bet settings block and resolves it on top
+// of defaults, as Validate and the miner do for a streamer's bet settings.
+func ResolveBetSettings(bsc *BetSettingsConfig, defaults *model.BetSettings) (*model.BetSettings, error) {
+	if err := validateBet(bsc); err != nil {
+		return nil, err
+	}
+	settings := bsc.ToBetSettings(defaults)
+	if err := validateCustomStrategy(settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// validateCustomStrategy checks that resolved bet settings using the CUSTOM
+// strategy, directly or through a title rule, have a choice expression. The
+// settings are resolved so a choice inherited from streamer_defaults counts.
+func validateCustomStrategy(bs *model.BetSettings) error {
+	hasChoice := bs.Custom != nil && bs.Custom.Choice != nil
+	if bs.Strategy == model.StrategyCustom && !hasChoice {
+		return fmt.Errorf("bet.strategy CUSTOM requires bet.custom.choice")
+	}
+	for i, rule := range bs.TitleRules {
+		if rule.Strategy != nil && *rule.Strategy == model.StrategyCustom && !hasChoice {
+			return fmt.Errorf("bet.title_rules[%d]: strategy CUSTOM requires bet.custom.choice", i)
+		}
+	}
+	return nil
+}
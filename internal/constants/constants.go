@@ -76,6 +76,18 @@ const (
 	MaxPubSubConns = 10
 	// MaxWatchStreams is the maximum number of streams to send minute-watched events for.
 	MaxWatchStreams = 2
+	// LedgerMaxEntries is the maximum number of resolved predictions kept in an
+	// account's prediction ledger. Older entries are dropped first.
+	LedgerMaxEntries = 5000
+	// KellyMinHistorySamples is the minimum number of resolved predictions with
+	// the same outcome count before KELLY sizing trusts a streamer's history
+	// over the current voter split.
+	KellyMinHistorySamples = 20
+	// MinBetAmount is the smallest stake Twitch accepts for a prediction.
+	MinBetAmount = 10
+	// RewardRedeemMaxFailures is how many failed attempts to redeem a reward
+	// the miner makes before giving up on it until the next stream.
+	RewardRedeemMaxFailures = 3
 )
 
 const (
@@ -93,6 +105,12 @@ const (
 	TopicCommunityMoments = "community-moments-channel-v1"
 	// TopicCommunityGoals is the PubSub topic for community goal events.
 	TopicCommunityGoals = "community-points-channel-v1"
+	// TopicDropEvents is the PubSub topic for the user's drop progress and claims.
+	TopicDropEvents = "user-drop-events"
+	// TopicHypeTrain is the PubSub topic for hype train events.
+	TopicHypeTrain = "hype-train-events-v1"
+	// TopicPolls is the PubSub topic for poll events.
+	TopicPolls = "polls"
 )
 
 const (
@@ -121,12 +139,70 @@ const (
 	DefaultCampaignSyncInterval = 10 * time.Minute
 	// DefaultCategoryWatcherInterval is the default interval for category watcher polling.
 	DefaultCategoryWatcherInterval = 120 * time.Second
+	// TopStreamsPageSize is the page size of category directory queries that
+	// filter streams client-side.
+	TopStreamsPageSize = 30
+	// TopStreamsMaxPages caps how many directory pages a single category
+	// query walks through to find enough matching streams.
+	TopStreamsMaxPages = 5
+	// DefaultFollowersRefreshInterval is how often followed channels are
+	// re-read to pick up new follows and unfollows.
+	DefaultFollowersRefreshInterval = 30 * time.Minute
+	// MinFollowersRefreshInterval is the shortest allowed followers refresh
+	// interval.
+	MinFollowersRefreshInterval = 1 * time.Minute
+	// FollowsPageSize is the page size used when reading followed channels.
+	FollowsPageSize = 100
+	// CategoryWatcherMaxSlots caps the slots of a single watched category.
+	CategoryWatcherMaxSlots = 10
+	// CategoryPickGracePeriod is how long a category-watched streamer has to
+	// stay live and in the category after being picked for the pick to count
+	// as reliable.
+	CategoryPickGracePeriod = 1 * time.Hour
+	// DefaultDropsWatcherInterval is the default interval for drops watcher polling.
+	DefaultDropsWatcherInterval = 5 * time.Minute
+	// DropsWatcherMaxChannelChecks caps how many allowlisted channels of a
+	// campaign the drops watcher checks for a live stream per poll.
+	DropsWatcherMaxChannelChecks = 15
+	// MaxStreamerSessions caps the broadcast sessions kept per streamer.
+	MaxStreamerSessions = 30
+	// DefaultRaidTargetTTL is how long a followed raid target is watched
+	// unless it goes offline first.
+	DefaultRaidTargetTTL = 1 * time.Hour
 	// DefaultStreamUpdateInterval is the interval for refreshing stream info.
 	DefaultStreamUpdateInterval = 120 * time.Second
 	// DefaultStreamUpDebounce is the debounce duration after a stream-up event.
 	DefaultStreamUpDebounce = 120 * time.Second
 	// DefaultGracefulShutdownTimeout is the timeout for graceful HTTP server shutdown.
 	DefaultGracefulShutdownTimeout = 5 * time.Second
+	// SharedChannelIDTTL is how long a login → channel ID mapping stays in the
+	// process-wide cache. Channel IDs never change, so this mostly bounds memory.
+	SharedChannelIDTTL = 24 * time.Hour
+	// SharedStreamInfoTTL is how long live stream info stays in the process-wide
+	// cache. Kept well below DefaultStreamUpdateInterval so each account still
+	// sees fresh data while concurrent accounts share a single request.
+	SharedStreamInfoTTL = 30 * time.Second
+	// SharedLookupTimeout bounds a lookup shared by several accounts through
+	// the process-wide cache, which runs detached from any caller's context.
+	SharedLookupTimeout = 30 * time.Second
+	// SharedOfflineStatusTTL is how long an "offline" result stays in the
+	// process-wide cache. Shorter than SharedStreamInfoTTL so go-lives are
+	// picked up quickly.
+	SharedOfflineStatusTTL = 15 * time.Second
+	// DefaultLosingStreakCooldown is how long betting pauses after a bankroll
+	// losing streak when no cooldown is configured.
+	DefaultLosingStreakCooldown = 12 * time.Hour
+	// PredictionRetryBaseDelay is the initial backoff between attempts to
+	// place a bet after a transient failure. It doubles on every attempt.
+	PredictionRetryBaseDelay = 500 * time.Millisecond
+	// PredictionRetryMaxDelay caps the backoff between bet placement attempts.
+	PredictionRetryMaxDelay = 4 * time.Second
+	// PredictionLockMargin is the minimum time left before a prediction locks
+	// for another bet placement attempt to be worthwhile.
+	PredictionLockMargin = 1 * time.Second
+	// PredictionConfirmGrace is how long after a prediction locks a placed bet
+	// may still be confirmed by a prediction-made message.
+	PredictionConfirmGrace = 15 * time.Second
 )
 
 // GQLOperation represents a persisted GQL query with its operation name and SHA256 hash.
@@ -214,9 +290,13 @@ var (
 		OperationName: "ContributeCommunityPointsCommunityGoal",
 		SHA256Hash:    "5774f0ea5d89587d73021a2e03c3c44777d903840c608754a1be519f51e37bb6",
 	}
+	GQLRedeemCustomReward = GQLOperation{
+		OperationName: "RedeemCustomReward",
+		Query:         `mutation RedeemCustomReward($input: RedeemCommunityPointsCustomRewardInput!) { redeemCommunityPointsCustomReward(input: $input) { error { code } redemption { id } } }`,
+	}
 	GQLDirectoryPageGame = GQLOperation{
 		OperationName: "DirectoryPage_Game",
-		Query:         `query DirectoryPage_Game($slug: String!, $first: Int!, $after: Cursor, $options: GameStreamOptions) { game(slug: $slug) { displayName name streams(first: $first, after: $after, options: $options) { edges { node { broadcaster { id login displayName } viewersCount title game { id name displayName slug } } cursor } pageInfo { hasNextPage } } } }`,
+		Query:         `query DirectoryPage_Game($slug: String!, $first: Int!, $after: Cursor, $options: GameStreamOptions) { game(slug: $slug) { displayName name streams(first: $first, after: $after, options: $options) { edges { node { broadcaster { id login displayName broadcastSettings { language isMature } } viewersCount title freeformTags { name } contentClassificationLabels { id } game { id name displayName slug } } cursor } pageInfo { hasNextPage } } } }`,
 	}
 	GQLGameByID = GQLOperation{
 		OperationName: "GameByID",
@@ -246,6 +326,7 @@ func AllGQLOperations() []GQLOperation {
 		GQLChannelFollows,
 		GQLUserPointsContribution,
 		GQLContributeCommunityPointsCommunityGoal,
+		GQLRedeemCustomReward,
 		GQLDirectoryPageGame,
 		GQLGameByID,
 	}
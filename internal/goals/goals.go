@@ -0,0 +1,34 @@
+// Package goals persists the points an account gave to community goals so
+// the per-goal limit survives restarts.
+package goals
+
+import (
+	"fmt"
+
+	"github.com/Guliveer/twitch-miner-go/internal/jsonutil"
+)
+
+// Path returns the goal contributions file path for an account, under the
+// goals directory of DATA_DIR.
+func Path(username string) string {
+	return jsonutil.DataPath("goals", username)
+}
+
+// Load reads the goal contributions stored at path, keyed by lowercase
+// streamer login and then goal ID. A missing file returns an error wrapping
+// os.ErrNotExist.
+func Load(path string) (map[string]map[string]int, error) {
+	var contributions map[string]map[string]int
+	if err := jsonutil.LoadFile(path, &contributions); err != nil {
+		return nil, fmt.Errorf("loading goal contributions: %w", err)
+	}
+	return contributions, nil
+}
+
+// Save writes the goal contributions to path atomically.
+func Save(path string, contributions map[string]map[string]int) error {
+	if err := jsonutil.SaveFile(path, contributions); err != nil {
+		return fmt.Errorf("saving goal contributions: %w", err)
+	}
+	return nil
+}
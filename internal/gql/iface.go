@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/Guliveer/twitch-miner-go/internal/constants"
+	"github.com/Guliveer/twitch-miner-go/internal/model"
 )
 
 // Operations is the interface for all GQL query/mutation methods.
@@ -32,9 +33,10 @@ type Operations interface {
 	JoinRaid(ctx context.Context, raidID string) error
 	GetPlaybackAccessToken(ctx context.Context, login string) (*PlaybackAccessToken, error)
 	ClaimCommunityMoment(ctx context.Context, momentID string) error
-	GetTopStreamsByCategory(ctx context.Context, categorySlug string, limit int, dropsOnly bool) ([]TopStream, error)
+	GetTopStreamsByCategory(ctx context.Context, categorySlug string, opts TopStreamsOptions) ([]TopStream, error)
 	ContributeToCommunityGoal(ctx context.Context, goalID, channelID string, points int, transactionID string) error
 	GetUserPointsContribution(ctx context.Context, channelLogin string) ([]GoalContribution, error)
+	RedeemCustomReward(ctx context.Context, channelID string, reward model.CustomReward, textInput, transactionID string) error
 	GetBroadcastID(ctx context.Context, channelID string) (string, error)
 	CheckViewerIsMod(ctx context.Context, channelLogin string) (bool, error)
 	GetGameSlug(ctx context.Context, gameID string) (string, error)
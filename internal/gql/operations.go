@@ -3,7 +3,9 @@ package gql
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/Guliveer/twitch-miner-go/internal/constants"
@@ -16,6 +18,7 @@ type ChannelPointsContext struct {
 	ActiveMultipliers []model.PointsMultiplier
 	AvailableClaimID string
 	CommunityGoals []*model.CommunityGoal
+	CustomRewards []model.CustomReward
 }
 
 // PlaybackAccessToken holds the signature and token needed for HLS manifest access.
@@ -33,6 +36,18 @@ type TopStream struct {
 	GameID string
 	GameName string
 	GameSlug string
+	Title string
+	Language string
+	Tags []string
+	IsMature bool // Flagged mature or carries content classification labels
+}
+
+// TopStreamsOptions narrows down the streams returned by GetTopStreamsByCategory.
+type TopStreamsOptions struct {
+	Limit int
+	DropsOnly bool
+	Languages []string // ISO 639-1 broadcaster languages; empty means any
+	Match func(*TopStream) bool // Client-side filter; nil keeps every stream
 }
 
 // GetChannelPointsContext fetches channel points balance, multipliers, available claims,
@@ -57,7 +72,18 @@ func (c *Client) GetChannelPointsContext(ctx context.Context, channelLogin strin
 					} `json:"communityPoints"`
 				} `json:"self"`
 				CommunityPointsSettings struct {
-					Goals []json.RawMessage `json:"goals"`
+					Goals         []json.RawMessage `json:"goals"`
+					CustomRewards []struct {
+						ID                  string `json:"id"`
+						Title               string `json:"title"`
+						Prompt              string `json:"prompt"`
+						Cost                int    `json:"cost"`
+						IsEnabled           bool   `json:"isEnabled"`
+						IsPaused            bool   `json:"isPaused"`
+						IsInStock           bool   `json:"isInStock"`
+						IsUserInputRequired bool   `json:"isUserInputRequired"`
+						CooldownExpiresAt   string `json:"cooldownExpiresAt"`
+					} `json:"customRewards"`
 				} `json:"communityPointsSettings"`
 			} `json:"channel"`
 		} `json:"community"`
@@ -95,6 +121,23 @@ func (c *Client) GetChannelPointsContext(ctx context.Context, channelLogin strin
 		}
 	}
 
+	for _, raw := range resp.Community.Channel.CommunityPointsSettings.CustomRewards {
+		reward := model.CustomReward{
+			ID:                  raw.ID,
+			Title:               raw.Title,
+			Prompt:              raw.Prompt,
+			Cost:                raw.Cost,
+			IsEnabled:           raw.IsEnabled,
+			IsPaused:            raw.IsPaused,
+			IsInStock:           raw.IsInStock,
+			IsUserInputRequired: raw.IsUserInputRequired,
+		}
+		if raw.CooldownExpiresAt != "" {
+			reward.CooldownExpiresAt, _ = time.Parse(time.RFC3339, raw.CooldownExpiresAt)
+		}
+		result.CustomRewards = append(result.CustomRewards, reward)
+	}
+
 	return result, nil
 }
 
@@ -215,13 +258,16 @@ func (c *Client) GetUserID(ctx context.Context, login string) (string, error) {
 }
 
 // GetFollowedStreamers fetches the list of followed channel logins for a user.
-// It paginates through all results.
+// It paginates through all results, limit being the page size.
 func (c *Client) GetFollowedStreamers(ctx context.Context, limit int, order string) ([]string, error) {
 	var follows []string
 	cursor := ""
 	hasNext := true
 
 	for hasNext {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("GetFollowedStreamers: %w", err)
+		}
 		vars := map[string]any{
 			"limit":  limit,
 			"order":  order,
@@ -257,18 +303,26 @@ func (c *Client) GetFollowedStreamers(ctx context.Context, limit int, order stri
 			return follows, nil
 		}
 
+		previousCursor := cursor
 		for _, edge := range resp.User.Follows.Edges {
 			follows = append(follows, edge.Node.Login)
 			cursor = edge.Cursor
 		}
 
-		hasNext = resp.User.Follows.PageInfo.HasNextPage
+		// A page that does not move the cursor would be fetched forever.
+		hasNext = resp.User.Follows.PageInfo.HasNextPage && cursor != previousCursor
 	}
 
 	return follows, nil
 }
 
-// MakePrediction places a prediction bet on an event.
+// ErrPredictionRejected is returned by MakePrediction when Twitch answered
+// with an error code (e.g. not enough points, event locked). Retrying the
+// same bet will not help.
+var ErrPredictionRejected = errors.New("prediction rejected")
+
+// MakePrediction places a prediction bet on an event. An empty response,
+// as returned when the integrity check fails, is reported as an error.
 func (c *Client) MakePrediction(ctx context.Context, eventID, outcomeID string, points int, transactionID string) error {
 	vars := map[string]any{
 		"input": map[string]any{
@@ -296,8 +350,11 @@ func (c *Client) MakePrediction(ctx context.Context, eventID, outcomeID string,
 		return fmt.Errorf("parsing MakePrediction response: %w", err)
 	}
 
-	if resp.MakePrediction != nil && resp.MakePrediction.Error != nil {
-		return fmt.Errorf("prediction error: %s", resp.MakePrediction.Error.Code)
+	if resp.MakePrediction == nil {
+		return fmt.Errorf("MakePrediction: empty response")
+	}
+	if resp.MakePrediction.Error != nil {
+		return fmt.Errorf("%w: %s", ErrPredictionRejected, resp.MakePrediction.Error.Code)
 	}
 
 	return nil
@@ -523,81 +580,138 @@ func (c *Client) ClaimCommunityMoment(ctx context.Context, momentID string) erro
 	return nil
 }
 
-// GetTopStreamsByCategory fetches top streams for a game category.
-func (c *Client) GetTopStreamsByCategory(ctx context.Context, categorySlug string, limit int, dropsOnly bool) ([]TopStream, error) {
+// GetTopStreamsByCategory fetches top streams for a game category. Streams
+// rejected by opts.Match do not count towards opts.Limit; further pages are
+// fetched via the directory cursor until enough streams match, the directory
+// ends, or TopStreamsMaxPages pages have been read.
+func (c *Client) GetTopStreamsByCategory(ctx context.Context, categorySlug string, opts TopStreamsOptions) ([]TopStream, error) {
+	pageSize := opts.Limit
+	if opts.Match != nil {
+		pageSize = max(opts.Limit, constants.TopStreamsPageSize)
+	}
 	vars := map[string]any{
 		"slug":  categorySlug,
-		"first": limit,
+		"first": pageSize,
 	}
 
-	if dropsOnly {
-		vars["options"] = map[string]any{
-			"tags": []string{constants.DropID},
-		}
+	options := map[string]any{}
+	if opts.DropsOnly {
+		options["tags"] = []string{constants.DropID}
 	}
-
-	data, err := c.PostGQL(ctx, constants.GQLDirectoryPageGame, vars)
-	if err != nil {
-		return nil, fmt.Errorf("GetTopStreamsByCategory for %s: %w", categorySlug, err)
+	if len(opts.Languages) > 0 {
+		languages := make([]string, len(opts.Languages))
+		for i, language := range opts.Languages {
+			languages[i] = strings.ToUpper(language)
+		}
+		options["broadcasterLanguages"] = languages
 	}
-
-	var resp struct {
-		Game *struct {
-			Streams struct {
-				Edges []struct {
-					Node struct {
-						Broadcaster struct {
-							ID          string `json:"id"`
-							Login       string `json:"login"`
-							DisplayName string `json:"displayName"`
-						} `json:"broadcaster"`
-						ViewersCount int `json:"viewersCount"`
-						Game         *struct {
-							ID          string `json:"id"`
-							DisplayName string `json:"displayName"`
-							Slug        string `json:"slug"`
-						} `json:"game"`
-					} `json:"node"`
-				} `json:"edges"`
-			} `json:"streams"`
-		} `json:"game"`
+	if len(options) > 0 {
+		vars["options"] = options
 	}
 
-	if err := json.Unmarshal(data, &resp); err != nil {
-		return nil, fmt.Errorf("parsing GetTopStreamsByCategory response: %w", err)
-	}
+	streams := make([]TopStream, 0, opts.Limit)
+	for page := 0; page < constants.TopStreamsMaxPages && len(streams) < opts.Limit; page++ {
+		data, err := c.PostGQL(ctx, constants.GQLDirectoryPageGame, vars)
+		if err != nil {
+			return nil, fmt.Errorf("GetTopStreamsByCategory for %s: %w", categorySlug, err)
+		}
 
-	if resp.Game == nil {
-		return nil, fmt.Errorf("category %s not found", categorySlug)
-	}
+		var resp struct {
+			Game *struct {
+				Streams struct {
+					Edges []struct {
+						Node struct {
+							Broadcaster struct {
+								ID                string `json:"id"`
+								Login             string `json:"login"`
+								DisplayName       string `json:"displayName"`
+								BroadcastSettings *struct {
+									Language string `json:"language"`
+									IsMature bool   `json:"isMature"`
+								} `json:"broadcastSettings"`
+							} `json:"broadcaster"`
+							ViewersCount int    `json:"viewersCount"`
+							Title        string `json:"title"`
+							FreeformTags []struct {
+								Name string `json:"name"`
+							} `json:"freeformTags"`
+							ContentClassificationLabels []struct {
+								ID string `json:"id"`
+							} `json:"contentClassificationLabels"`
+							Game *struct {
+								ID          string `json:"id"`
+								DisplayName string `json:"displayName"`
+								Slug        string `json:"slug"`
+							} `json:"game"`
+						} `json:"node"`
+						Cursor string `json:"cursor"`
+					} `json:"edges"`
+					PageInfo struct {
+						HasNextPage bool `json:"hasNextPage"`
+					} `json:"pageInfo"`
+				} `json:"streams"`
+			} `json:"game"`
+		}
 
-	streams := make([]TopStream, 0, len(resp.Game.Streams.Edges))
-	for _, edge := range resp.Game.Streams.Edges {
-		node := edge.Node
-		topStream := TopStream{
-			Username:     node.Broadcaster.Login,
-			ChannelID:    node.Broadcaster.ID,
-			DisplayName:  node.Broadcaster.DisplayName,
-			ViewersCount: node.ViewersCount,
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return nil, fmt.Errorf("parsing GetTopStreamsByCategory response: %w", err)
 		}
-		if node.Game != nil {
-			topStream.GameID = node.Game.ID
-			topStream.GameName = node.Game.DisplayName
-			topStream.GameSlug = node.Game.Slug
+
+		if resp.Game == nil {
+			return nil, fmt.Errorf("category %s not found", categorySlug)
 		}
 
-		if topStream.ChannelID == "" {
-			if topStream.Username != "" {
-				if id, err := c.GetUserID(ctx, topStream.Username); err == nil {
-					topStream.ChannelID = id
-				}
+		cursor := ""
+		for _, edge := range resp.Game.Streams.Edges {
+			cursor = edge.Cursor
+			if len(streams) >= opts.Limit {
+				break
 			}
-			if topStream.ChannelID == "" {
+
+			node := edge.Node
+			topStream := TopStream{
+				Username:     node.Broadcaster.Login,
+				ChannelID:    node.Broadcaster.ID,
+				DisplayName:  node.Broadcaster.DisplayName,
+				ViewersCount: node.ViewersCount,
+				Title:        node.Title,
+				IsMature:     len(node.ContentClassificationLabels) > 0,
+			}
+			if settings := node.Broadcaster.BroadcastSettings; settings != nil {
+				topStream.Language = settings.Language
+				topStream.IsMature = topStream.IsMature || settings.IsMature
+			}
+			for _, tag := range node.FreeformTags {
+				topStream.Tags = append(topStream.Tags, tag.Name)
+			}
+			if node.Game != nil {
+				topStream.GameID = node.Game.ID
+				topStream.GameName = node.Game.DisplayName
+				topStream.GameSlug = node.Game.Slug
+			}
+
+			if opts.Match != nil && !opts.Match(&topStream) {
 				continue
 			}
+
+			if topStream.ChannelID == "" {
+				if topStream.Username != "" {
+					if id, err := c.GetUserID(ctx, topStream.Username); err == nil {
+						topStream.ChannelID = id
+					}
+				}
+				if topStream.ChannelID == "" {
+					continue
+				}
+			}
+
+			streams = append(streams, topStream)
 		}
 
-		streams = append(streams, topStream)
+		if !resp.Game.Streams.PageInfo.HasNextPage || cursor == "" {
+			break
+		}
+		vars["after"] = cursor
 	}
 
 	return streams, nil
@@ -639,6 +753,49 @@ func (c *Client) ContributeToCommunityGoal(ctx context.Context, goalID, channelI
 	return nil
 }
 
+// RedeemCustomReward redeems a channel's custom reward. textInput is sent
+// only for rewards that require user input.
+func (c *Client) RedeemCustomReward(ctx context.Context, channelID string, reward model.CustomReward, textInput, transactionID string) error {
+	input := map[string]any{
+		"channelID":     channelID,
+		"cost":          reward.Cost,
+		"prompt":        reward.Prompt,
+		"rewardID":      reward.ID,
+		"title":         reward.Title,
+		"transactionID": transactionID,
+	}
+	if reward.IsUserInputRequired {
+		input["textInput"] = textInput
+	}
+
+	data, err := c.PostGQL(ctx, constants.GQLRedeemCustomReward, map[string]any{"input": input})
+	if err != nil {
+		return fmt.Errorf("RedeemCustomReward: %w", err)
+	}
+
+	var resp struct {
+		RedeemCommunityPointsCustomReward *struct {
+			Error *struct {
+				Code string `json:"code"`
+			} `json:"error"`
+		} `json:"redeemCommunityPointsCustomReward"`
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return fmt.Errorf("parsing RedeemCustomReward response: %w", err)
+	}
+
+	if resp.RedeemCommunityPointsCustomReward == nil {
+		return fmt.Errorf("RedeemCustomReward: empty response")
+	}
+	if resp.RedeemCommunityPointsCustomReward.Error != nil {
+		return fmt.Errorf("reward redemption error: %s",
+			resp.RedeemCommunityPointsCustomReward.Error.Code)
+	}
+
+	return nil
+}
+
 // GetUserPointsContribution fetches the user's points contribution data for a channel.
 func (c *Client) GetUserPointsContribution(ctx context.Context, channelLogin string) ([]GoalContribution, error) {
 	vars := map[string]any{"channelLogin": channelLogin}
@@ -0,0 +1,55 @@
+package jsonutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DataPath returns the path of an account's JSON file under dir.
+// On Fly.io / Docker, DATA_DIR points to the persistent volume (e.g. /data),
+// so the file is stored under {DATA_DIR}/{dir}/{username}.json.
+func DataPath(dir, username string) string {
+	if dataDir := os.Getenv("DATA_DIR"); dataDir != "" {
+		dir = filepath.Join(dataDir, dir)
+	}
+	return filepath.Join(dir, strings.ToLower(username)+".json")
+}
+
+// LoadFile reads the JSON file at path into v. A missing file returns an
+// error wrapping os.ErrNotExist.
+func LoadFile(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return nil
+}
+
+// SaveFile writes v to path as JSON atomically, creating the directory if
+// needed.
+func SaveFile(path string, v any) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("creating directory %s: %w", dir, err)
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("marshaling %s: %w", path, err)
+	}
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
+		return fmt.Errorf("writing temp file %s: %w", tmpPath, err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("renaming temp file %s to %s: %w", tmpPath, path, err)
+	}
+	return nil
+}
@@ -1,5 +1,6 @@
 // Package jsonutil provides helper functions for extracting typed values
-// from unstructured JSON maps (map[string]any).
+// from unstructured JSON maps (map[string]any) and for storing per-account
+// JSON files.
 package jsonutil
 
 import "encoding/json"
@@ -0,0 +1,277 @@
+// Package ledger persists resolved predictions for an account so betting
+// decisions can be informed by, and replayed against, real history.
+package ledger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Guliveer/twitch-miner-go/internal/constants"
+	"github.com/Guliveer/twitch-miner-go/internal/model"
+)
+
+// ResultSkipped is the result of a prediction a title rule told the miner not
+// to bet on. Such entries record that the rule fired; they hold no stake and
+// do not count as bets.
+const ResultSkipped = "SKIPPED"
+
+// Entry is a single resolved prediction the account bet on, or one skipped
+// by a title rule.
+type Entry struct {
+	EventID string `json:"event_id"`
+	Streamer string `json:"streamer"`
+	Title string `json:"title"`
+	Outcomes []model.Outcome `json:"outcomes"`
+	Balance int `json:"balance"`
+	PredictionWindow float64 `json:"prediction_window,omitempty"` // Seconds the prediction was open for
+	Strategy string `json:"strategy"`
+	Sizing string `json:"sizing"`
+	Decision model.BetDecision `json:"decision"`
+	Rule string `json:"rule,omitempty"`
+	Result string `json:"result"`
+	Placed int `json:"placed"`
+	Won int `json:"won"`
+	Gained int `json:"gained"`
+	WinningOutcomeID string `json:"winning_outcome_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	ResolvedAt time.Time `json:"resolved_at"`
+}
+
+// WinnerIndex returns the index of the winning outcome, or -1 if unknown.
+// Without an explicit winning outcome ID the winner is inferred from our own
+// result, which is only unambiguous for a win or a two-outcome loss.
+func (e *Entry) WinnerIndex() int {
+	if e.WinningOutcomeID != "" {
+		for i, o := range e.Outcomes {
+			if o.ID == e.WinningOutcomeID {
+				return i
+			}
+		}
+	}
+
+	choice := e.Decision.Choice
+	if choice < 0 || choice >= len(e.Outcomes) {
+		return -1
+	}
+	switch e.Result {
+	case "WIN":
+		return choice
+	case "LOSE":
+		if len(e.Outcomes) == 2 {
+			return 1 - choice
+		}
+	}
+	return -1
+}
+
+// Ledger is a thread-safe, file-backed list of prediction entries.
+// At most constants.LedgerMaxEntries are kept; the oldest are dropped first.
+type Ledger struct {
+	mu      sync.RWMutex
+	path    string
+	entries []Entry
+}
+
+// Path returns the ledger file path for an account.
+// On Fly.io / Docker, DATA_DIR points to the persistent volume (e.g. /data),
+// so the ledger is stored under {DATA_DIR}/ledger/{username}.json.
+func Path(username string) string {
+	dir := "ledger"
+	if dataDir := os.Getenv("DATA_DIR"); dataDir != "" {
+		dir = filepath.Join(dataDir, "ledger")
+	}
+	return filepath.Join(dir, strings.ToLower(username)+".json")
+}
+
+// Open loads the ledger stored at path. A missing file yields an empty ledger.
+// On a parse error the returned ledger is still usable but starts empty.
+func Open(path string) (*Ledger, error) {
+	l := &Ledger{path: path}
+
+	entries, err := Load(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return l, err
+	}
+	l.entries = entries
+	return l, nil
+}
+
+// Load reads the entries of a ledger file without opening it for writing.
+func Load(path string) ([]Entry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading ledger file %s: %w", path, err)
+	}
+
+	var entries []Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("parsing ledger file %s: %w", path, err)
+	}
+	return entries, nil
+}
+
+// Record appends an entry and persists the ledger.
+func (l *Ledger) Record(entry Entry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.entries = append(l.entries, entry)
+	if over := len(l.entries) - constants.LedgerMaxEntries; over > 0 {
+		l.entries = append([]Entry(nil), l.entries[over:]...)
+	}
+	return l.save()
+}
+
+// SetWinner records the winning outcome of an event. It is a no-op if the
+// event is not in the ledger or its winner is already known.
+func (l *Ledger) SetWinner(eventID, outcomeID string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for i := len(l.entries) - 1; i >= 0; i-- {
+		if l.entries[i].EventID != eventID {
+			continue
+		}
+		if l.entries[i].WinningOutcomeID == outcomeID {
+			return nil
+		}
+		l.entries[i].WinningOutcomeID = outcomeID
+		return l.save()
+	}
+	return nil
+}
+
+// Entries returns a copy of all entries, oldest first.
+func (l *Ledger) Entries() []Entry {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	out := make([]Entry, len(l.entries))
+	copy(out, l.entries)
+	return out
+}
+
+// WinRates returns how often each outcome index won in past predictions of
+// a streamer that had the same number of outcomes, along with the number of
+// samples. Rates use Laplace smoothing so no outcome is ever certain.
+func (l *Ledger) WinRates(streamer string, outcomes int) ([]float64, int) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return WinRates(l.entries, streamer, outcomes)
+}
+
+// WinRates is Ledger.WinRates over a given list of entries, e.g. the
+// entries that precede a prediction replayed by a backtest.
+func WinRates(entries []Entry, streamer string, outcomes int) ([]float64, int) {
+	if outcomes <= 0 {
+		return nil, 0
+	}
+
+	wins := make([]int, outcomes)
+	samples := 0
+	for i := range entries {
+		e := &entries[i]
+		if !strings.EqualFold(e.Streamer, streamer) || len(e.Outcomes) != outcomes {
+			continue
+		}
+		if idx := e.WinnerIndex(); idx >= 0 {
+			wins[idx]++
+			samples++
+		}
+	}
+
+	rates := make([]float64, outcomes)
+	for i, w := range wins {
+		rates[i] = float64(w+1) / float64(samples+outcomes)
+	}
+	return rates, samples
+}
+
+// NetSince returns the net points gained by predictions resolved at or
+// after since. An empty streamer matches every streamer.
+func (l *Ledger) NetSince(streamer string, since time.Time) int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	net := 0
+	for i := range l.entries {
+		e := &l.entries[i]
+		if matchesStreamer(e, streamer) && !e.ResolvedAt.Before(since) {
+			net += e.Gained
+		}
+	}
+	return net
+}
+
+// CountSince returns the number of bets on predictions created at or after
+// since. An empty streamer matches every streamer.
+func (l *Ledger) CountSince(streamer string, since time.Time) int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	count := 0
+	for i := range l.entries {
+		e := &l.entries[i]
+		if matchesStreamer(e, streamer) && e.Result != ResultSkipped && !e.CreatedAt.Before(since) {
+			count++
+		}
+	}
+	return count
+}
+
+// LosingStreak returns the number of consecutive losses at the end of the
+// ledger and when the last of them resolved. Refunds and skipped predictions
+// neither extend nor break a streak. An empty streamer matches every streamer.
+func (l *Ledger) LosingStreak(streamer string) (int, time.Time) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	streak := 0
+	var lastLoss time.Time
+	for i := len(l.entries) - 1; i >= 0; i-- {
+		e := &l.entries[i]
+		if !matchesStreamer(e, streamer) || e.Result == "REFUND" || e.Result == ResultSkipped {
+			continue
+		}
+		if e.Result != "LOSE" {
+			break
+		}
+		if streak == 0 {
+			lastLoss = e.ResolvedAt
+		}
+		streak++
+	}
+	return streak, lastLoss
+}
+
+func matchesStreamer(e *Entry, streamer string) bool {
+	return streamer == "" || strings.EqualFold(e.Streamer, streamer)
+}
+
+// save writes the ledger to disk atomically. Must be called with mu held.
+func (l *Ledger) save() error {
+	dir := filepath.Dir(l.path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("creating ledger directory %s: %w", dir, err)
+	}
+
+	data, err := json.Marshal(l.entries)
+	if err != nil {
+		return fmt.Errorf("marshaling ledger: %w", err)
+	}
+
+	tmpPath := l.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
+		return fmt.Errorf("writing temp ledger file %s: %w", tmpPath, err)
+	}
+	if err := os.Rename(tmpPath, l.path); err != nil {
+		return fmt.Errorf("renaming temp ledger file %s to %s: %w", tmpPath, l.path, err)
+	}
+	return nil
+}
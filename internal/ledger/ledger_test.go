@@ -0,0 +1,209 @@
+package ledger
+
+import (
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Guliveer/twitch-miner-go/internal/model"
+)
+
+func twoOutcomes() []model.Outcome {
+	return []model.Outcome{{ID: "blue"}, {ID: "pink"}}
+}
+
+// openTemp opens a ledger in a temp dir, records entries and reopens it so
+// every test also reads the entries back from disk.
+func openTemp(t *testing.T, entries ...Entry) *Ledger {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "account.json")
+	l, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	for _, e := range entries {
+		if err := l.Record(e); err != nil {
+			t.Fatalf("Record: %v", err)
+		}
+	}
+
+	l, err = Open(path)
+	if err != nil {
+		t.Fatalf("reopening ledger: %v", err)
+	}
+	if got := len(l.Entries()); got != len(entries) {
+		t.Fatalf("reopened ledger has %d entries, want %d", got, len(entries))
+	}
+	return l
+}
+
+func TestWinRates(t *testing.T) {
+	win := func(choice int) Entry {
+		return Entry{Streamer: "alice", Outcomes: twoOutcomes(), Decision: model.BetDecision{Choice: choice}, Result: "WIN"}
+	}
+	l := openTemp(t,
+		win(0), win(0), win(0),
+		Entry{Streamer: "alice", Outcomes: twoOutcomes(), Decision: model.BetDecision{Choice: 0}, Result: "LOSE"}, // pink won
+		Entry{Streamer: "alice", Outcomes: twoOutcomes(), Result: "REFUND"},                                       // no winner
+		Entry{Streamer: "alice", Outcomes: []model.Outcome{{ID: "a"}, {ID: "b"}, {ID: "c"}}, Result: "WIN"},       // other outcome count
+		Entry{Streamer: "bob", Outcomes: twoOutcomes(), Decision: model.BetDecision{Choice: 1}, Result: "WIN"},    // other streamer
+		Entry{Streamer: "ALICE", Outcomes: twoOutcomes(), WinningOutcomeID: "pink", Result: "LOSE"},               // explicit winner
+	)
+
+	tests := []struct {
+		name        string
+		streamer    string
+		outcomes    int
+		wantRates   []float64
+		wantSamples int
+	}{
+		{"history", "alice", 2, []float64{4.0 / 7, 3.0 / 7}, 5},
+		{"no history", "carol", 2, []float64{0.5, 0.5}, 0},
+		{"no outcomes", "alice", 0, nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rates, samples := l.WinRates(tt.streamer, tt.outcomes)
+			if samples != tt.wantSamples {
+				t.Errorf("samples = %d, want %d", samples, tt.wantSamples)
+			}
+			if len(rates) != len(tt.wantRates) {
+				t.Fatalf("rates = %v, want %v", rates, tt.wantRates)
+			}
+			for i := range rates {
+				if math.Abs(rates[i]-tt.wantRates[i]) > 1e-9 {
+					t.Errorf("rates = %v, want %v", rates, tt.wantRates)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestNetSinceAndCountSince(t *testing.T) {
+	now := time.Now().UTC()
+	entry := func(streamer string, age time.Duration, gained int) Entry {
+		return Entry{
+			Streamer:   streamer,
+			Gained:     gained,
+			CreatedAt:  now.Add(-age - time.Minute),
+			ResolvedAt: now.Add(-age),
+		}
+	}
+	l := openTemp(t,
+		entry("alice", 48*time.Hour, -1000),
+		entry("alice", 2*time.Hour, -300),
+		entry("alice", time.Hour, 200),
+		entry("bob", 30*time.Minute, -50),
+	)
+
+	since := now.Add(-24 * time.Hour)
+	tests := []struct {
+		name      string
+		streamer  string
+		since     time.Time
+		wantNet   int
+		wantCount int
+	}{
+		{"streamer last day", "alice", since, -100, 2},
+		{"streamer case-insensitive", "Alice", since, -100, 2},
+		{"all streamers last day", "", since, -150, 3},
+		{"all streamers all time", "", time.Time{}, -1150, 4},
+		{"unknown streamer", "carol", since, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := l.NetSince(tt.streamer, tt.since); got != tt.wantNet {
+				t.Errorf("NetSince = %d, want %d", got, tt.wantNet)
+			}
+			if got := l.CountSince(tt.streamer, tt.since); got != tt.wantCount {
+				t.Errorf("CountSince = %d, want %d", got, tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestLosingStreak(t *testing.T) {
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	result := func(streamer, res string, minute int) Entry {
+		return Entry{Streamer: streamer, Result: res, ResolvedAt: base.Add(time.Duration(minute) * time.Minute)}
+	}
+
+	tests := []struct {
+		name         string
+		entries      []Entry
+		streamer     string
+		wantStreak   int
+		wantLastLoss time.Time
+	}{
+		{
+			name:     "empty ledger",
+			streamer: "alice",
+		},
+		{
+			name:     "ends with a win",
+			entries:  []Entry{result("alice", "LOSE", 1), result("alice", "WIN", 2)},
+			streamer: "alice",
+		},
+		{
+			name: "losses after a win",
+			entries: []Entry{
+				result("alice", "WIN", 1),
+				result("alice", "LOSE", 2),
+				result("alice", "LOSE", 3),
+			},
+			streamer:     "alice",
+			wantStreak:   2,
+			wantLastLoss: base.Add(3 * time.Minute),
+		},
+		{
+			name: "refunds do not break the streak",
+			entries: []Entry{
+				result("alice", "LOSE", 1),
+				result("alice", "REFUND", 2),
+				result("alice", "LOSE", 3),
+				result("alice", "REFUND", 4),
+			},
+			streamer:     "alice",
+			wantStreak:   2,
+			wantLastLoss: base.Add(3 * time.Minute),
+		},
+		{
+			name: "other streamers are ignored",
+			entries: []Entry{
+				result("alice", "LOSE", 1),
+				result("bob", "WIN", 2),
+			},
+			streamer:     "alice",
+			wantStreak:   1,
+			wantLastLoss: base.Add(time.Minute),
+		},
+		{
+			name: "all streamers",
+			entries: []Entry{
+				result("alice", "LOSE", 1),
+				result("bob", "WIN", 2),
+				result("alice", "LOSE", 3),
+			},
+			wantStreak:   1,
+			wantLastLoss: base.Add(3 * time.Minute),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := openTemp(t, tt.entries...)
+			streak, lastLoss := l.LosingStreak(tt.streamer)
+			if streak != tt.wantStreak {
+				t.Errorf("streak = %d, want %d", streak, tt.wantStreak)
+			}
+			if !lastLoss.Equal(tt.wantLastLoss) {
+				t.Errorf("last loss = %s, want %s", lastLoss, tt.wantLastLoss)
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ import (
 var eventEmoji = map[string]string{
 	"GAIN_FOR_WATCH":        "📺",
 	"GAIN_FOR_WATCH_STREAK": "📺",
+	"WATCH_STREAK_AT_RISK":  "⏳",
 	"GAIN_FOR_CLAIM":        "🎁",
 	"GAIN_FOR_RAID":         "🎁",
 	"BONUS_CLAIM":           "💰",
@@ -29,13 +30,22 @@ var eventEmoji = map[string]string{
 	"BET_FILTERS":           "🎰",
 	"BET_GENERAL":           "🎰",
 	"BET_FAILED":            "🎰",
+	"BET_UNCONFIRMED":       "⚠️",
+	"BET_LOCKED":            "🔒",
 	"DROP_CLAIM":            "📦",
 	"DROP_STATUS":           "📦",
 	"STREAMER_ONLINE":       "🟢",
 	"STREAMER_OFFLINE":      "⚫",
+	"SESSION_SUMMARY":       "📋",
 	"JOIN_RAID":             "⚔️",
+	"RAID_SKIPPED":          "🛡️",
 	"CHAT_MENTION":          "💬",
 	"MOMENT_CLAIM":          "🎉",
+	"REWARD_REDEEM":         "🛒",
+	"GOAL_CONTRIBUTE":       "🤝",
+	"HYPE_TRAIN_START":      "🚂",
+	"HYPE_TRAIN_LEVEL":      "🚂",
+	"POLL_START":            "📊",
 }
 
 // ANSI color codes for terminal output.
@@ -0,0 +1,93 @@
+package miner
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Guliveer/twitch-miner-go/internal/model"
+)
+
+// accountBankroll returns the account-wide bankroll rules.
+func (m *Miner) accountBankroll() model.BankrollSettings {
+	return m.cfg.Bankroll.ToBankrollSettings(model.BankrollSettings{})
+}
+
+// bankrollBlockReason checks the account-level and per-streamer bankroll
+// rules against the prediction ledger. It returns why a new bet on the
+// streamer must not be placed, or "" if betting is allowed.
+func (m *Miner) bankrollBlockReason(username string, onlineAt time.Time, balance int, streamerRules model.BankrollSettings) string {
+	if reason := m.checkBankroll("", username, onlineAt, balance, m.accountBankroll()); reason != "" {
+		return "account " + reason
+	}
+	if reason := m.checkBankroll(username, username, onlineAt, balance, streamerRules); reason != "" {
+		return reason
+	}
+	return ""
+}
+
+// checkBankroll evaluates one set of bankroll rules for a bet on username.
+// Loss limits and losing streaks are read from the ledger entries of scope;
+// an empty scope aggregates the ledger over all streamers. The balance floor
+// and the bets per stream always apply to username's channel.
+func (m *Miner) checkBankroll(scope, username string, onlineAt time.Time, balance int, rules model.BankrollSettings) string {
+	now := time.Now()
+
+	if rules.BalanceFloor > 0 && balance <= rules.BalanceFloor {
+		return fmt.Sprintf("balance %d is at or below floor %d", balance, rules.BalanceFloor)
+	}
+
+	if rules.DailyLossLimit > 0 {
+		if lost := -m.ledger.NetSince(scope, now.Add(-24*time.Hour)); lost >= rules.DailyLossLimit {
+			return fmt.Sprintf("daily loss limit reached (lost %d of %d)", lost, rules.DailyLossLimit)
+		}
+	}
+
+	if rules.WeeklyLossLimit > 0 {
+		if lost := -m.ledger.NetSince(scope, now.Add(-7*24*time.Hour)); lost >= rules.WeeklyLossLimit {
+			return fmt.Sprintf("weekly loss limit reached (lost %d of %d)", lost, rules.WeeklyLossLimit)
+		}
+	}
+
+	if rules.MaxBetsPerStream > 0 && !onlineAt.IsZero() {
+		if bets := m.ledger.CountSince(username, onlineAt) + m.pendingBets(username); bets >= rules.MaxBetsPerStream {
+			return fmt.Sprintf("max bets per stream reached (%d of %d)", bets, rules.MaxBetsPerStream)
+		}
+	}
+
+	if rules.LosingStreak > 0 {
+		streak, lastLoss := m.ledger.LosingStreak(scope)
+		if streak >= rules.LosingStreak {
+			if resumeAt := lastLoss.Add(rules.Cooldown); now.Before(resumeAt) {
+				return fmt.Sprintf("paused after %d consecutive losses until %s", streak, resumeAt.Format(time.RFC3339))
+			}
+		}
+	}
+
+	return ""
+}
+
+// pendingBets counts bets placed on a streamer's predictions that have not
+// resolved yet and are therefore not in the ledger.
+func (m *Miner) pendingBets(username string) int {
+	m.eventsPredictionsMu.RLock()
+	placed := make([]*model.Streamer, 0, len(m.eventsPredictions))
+	for _, event := range m.eventsPredictions {
+		event.Mu.Lock()
+		if event.BetPlaced && event.Streamer != nil {
+			placed = append(placed, event.Streamer)
+		}
+		event.Mu.Unlock()
+	}
+	m.eventsPredictionsMu.RUnlock()
+
+	count := 0
+	for _, s := range placed {
+		s.Mu.RLock()
+		if strings.EqualFold(s.Username, username) {
+			count++
+		}
+		s.Mu.RUnlock()
+	}
+	return count
+}
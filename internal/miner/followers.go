@@ -0,0 +1,167 @@
+package miner
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/Guliveer/twitch-miner-go/internal/config"
+	"github.com/Guliveer/twitch-miner-go/internal/constants"
+	"github.com/Guliveer/twitch-miner-go/internal/model"
+)
+
+// loadFollows returns the lowercased logins of all channels the account
+// follows.
+func (m *Miner) loadFollows(ctx context.Context) ([]string, error) {
+	follows, err := m.twitch.GetFollowers(ctx, constants.FollowsPageSize, m.cfg.Followers.Order)
+	if err != nil {
+		return nil, err
+	}
+	for i, login := range follows {
+		follows[i] = strings.ToLower(strings.TrimSpace(login))
+	}
+	return follows, nil
+}
+
+// runFollowersRefresh re-reads the followed channels every
+// followers.refresh_interval and applies the changes.
+func (m *Miner) runFollowersRefresh(ctx context.Context) error {
+	if !m.cfg.Followers.Enabled {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	ticker := time.NewTicker(m.cfg.Followers.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			m.refreshFollows(ctx)
+		}
+	}
+}
+
+// refreshFollows adds newly followed channels and removes unfollowed ones.
+// Unfollowed channels listed in streamers, and streamers tracked by a
+// watcher or as a raid target, are kept. Only called from
+// runFollowersRefresh.
+func (m *Miner) refreshFollows(ctx context.Context) {
+	follows, err := m.loadFollows(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			m.log.Warn("Failed to refresh followers", "error", err)
+		}
+		return
+	}
+
+	current := make(map[string]bool, len(follows))
+	for _, login := range follows {
+		current[login] = true
+	}
+
+	explicit := make(map[string]bool, len(m.cfg.Streamers))
+	for _, sc := range m.cfg.Streamers {
+		explicit[strings.ToLower(strings.TrimSpace(sc.Username))] = true
+	}
+
+	tracked := make(map[string]*model.Streamer)
+	for _, s := range m.getStreamers() {
+		tracked[strings.ToLower(s.Username)] = s
+	}
+
+	added, removed := 0, 0
+	for _, login := range follows {
+		if ctx.Err() != nil {
+			return
+		}
+		if tracked[login] != nil || m.isBlacklisted(login) {
+			continue
+		}
+		if m.addFollow(ctx, login) {
+			added++
+		}
+	}
+
+	for login := range m.followed {
+		if current[login] || explicit[login] {
+			continue
+		}
+		s := tracked[login]
+		if s == nil {
+			continue
+		}
+		s.Mu.RLock()
+		owned := s.IsCategoryWatched || s.DropsCampaignID != "" || s.RaidFrom != ""
+		s.Mu.RUnlock()
+		if owned {
+			continue
+		}
+		m.removeStreamerWithReason(login, "unfollowed")
+		removed++
+	}
+
+	m.followed = current
+
+	if added > 0 || removed > 0 {
+		m.log.Info("📋 Followers refreshed",
+			"count", len(follows),
+			"added", added,
+			"removed", removed,
+		)
+	}
+}
+
+// addFollow starts tracking a newly followed channel with the default
+// streamer settings. It reports whether the channel was added; a channel
+// that another component started tracking meanwhile is not added twice.
+func (m *Miner) addFollow(ctx context.Context, login string) bool {
+	if m.isTracked(login) {
+		return false
+	}
+
+	channelID, err := m.twitch.GetChannelID(ctx, login)
+	if err != nil || channelID == "" {
+		m.log.Warn("Failed to resolve followed channel",
+			"streamer", login, "error", err)
+		return false
+	}
+
+	streamer := model.NewStreamer(login)
+	streamer.ChannelID = channelID
+	streamer.AccountUsername = m.cfg.Username
+	streamer.Settings = (&config.StreamerSettingsConfig{}).ToStreamerSettings(m.getStreamerDefaults())
+
+	if err := m.twitch.CheckStreamerOnline(ctx, streamer); err != nil {
+		m.log.Debug("Online check failed for followed channel",
+			"streamer", login, "error", err)
+	}
+	if err := m.twitch.LoadChannelPointsContext(ctx, streamer); err != nil {
+		m.log.Warn("Failed to load channel points context",
+			"streamer", login, "error", err)
+	}
+
+	streamer.Mu.RLock()
+	online := streamer.IsOnline
+	streamer.Mu.RUnlock()
+
+	if !m.addStreamer(ctx, streamer) {
+		return false
+	}
+	m.updateChatPresence(streamer, online)
+	return true
+}
+
+// isTracked reports whether a streamer with the login is tracked.
+func (m *Miner) isTracked(login string) bool {
+	m.streamersMu.RLock()
+	defer m.streamersMu.RUnlock()
+	for _, s := range m.streamers {
+		if strings.EqualFold(s.Username, login) {
+			return true
+		}
+	}
+	return false
+}
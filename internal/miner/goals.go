@@ -0,0 +1,79 @@
+package miner
+
+import (
+	"errors"
+	"maps"
+	"os"
+	"strings"
+
+	"github.com/Guliveer/twitch-miner-go/internal/goals"
+	"github.com/Guliveer/twitch-miner-go/internal/model"
+)
+
+// loadGoalContributions reads the persisted community goal contributions of
+// the account and applies them to the tracked streamers.
+func (m *Miner) loadGoalContributions() {
+	contributions, err := goals.Load(goals.Path(m.cfg.Username))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		m.log.Warn("Failed to load goal contributions, starting empty", "error", err)
+	}
+	if contributions == nil {
+		contributions = make(map[string]map[string]int)
+	}
+
+	m.goalsMu.Lock()
+	m.savedGoals = contributions
+	m.goalsMu.Unlock()
+
+	for _, s := range m.getStreamers() {
+		m.restoreGoalContributions(s)
+	}
+}
+
+// restoreGoalContributions applies the persisted goal contributions of a
+// streamer, if any.
+func (m *Miner) restoreGoalContributions(s *model.Streamer) {
+	m.goalsMu.Lock()
+	saved, ok := m.savedGoals[strings.ToLower(s.Username)]
+	m.goalsMu.Unlock()
+	if !ok {
+		return
+	}
+
+	s.Mu.Lock()
+	s.GoalContributions = maps.Clone(saved)
+	s.Mu.Unlock()
+}
+
+// saveGoalContributions persists the goal contributions of the tracked
+// streamers if any changed since the last save. Contributions of streamers
+// that are no longer tracked are kept.
+func (m *Miner) saveGoalContributions() {
+	m.goalsMu.Lock()
+	defer m.goalsMu.Unlock()
+
+	if m.savedGoals == nil {
+		return
+	}
+
+	contributions := maps.Clone(m.savedGoals)
+	for _, s := range m.getStreamers() {
+		login := strings.ToLower(s.Username)
+		s.Mu.RLock()
+		if len(s.GoalContributions) > 0 {
+			contributions[login] = maps.Clone(s.GoalContributions)
+		} else {
+			delete(contributions, login)
+		}
+		s.Mu.RUnlock()
+	}
+	if maps.EqualFunc(contributions, m.savedGoals, maps.Equal) {
+		return
+	}
+
+	if err := goals.Save(goals.Path(m.cfg.Username), contributions); err != nil {
+		m.log.Warn("Failed to save goal contributions", "error", err)
+		return
+	}
+	m.savedGoals = contributions
+}
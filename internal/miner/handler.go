@@ -3,6 +3,7 @@ package miner
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/Guliveer/twitch-miner-go/internal/jsonutil"
@@ -33,6 +34,12 @@ func (m *Miner) handleMessage(ctx context.Context, msg *model.Message) {
 		m.handleCommunityMoments(ctx, msg, streamer)
 	case "community-points-channel-v1":
 		m.handleCommunityGoals(ctx, msg, streamer)
+	case "user-drop-events":
+		m.handleDropEvents(ctx, msg)
+	case "hype-train-events-v1":
+		m.handleHypeTrain(ctx, msg, streamer)
+	case "polls":
+		m.handlePolls(ctx, msg, streamer)
 	default:
 		m.log.Debug("Unhandled PubSub topic", "topic", msg.Topic, "type", string(msg.Type))
 	}
@@ -77,6 +84,9 @@ func (m *Miner) handlePointsEarnedOrSpent(ctx context.Context, msg *model.Messag
 		if streamer != nil {
 			streamer.Mu.Lock()
 			streamer.UpdateHistory(reasonCode, earned, 1)
+			if reasonCode == "WATCH_STREAK" {
+				streamer.Streak.Earn(streamer.Stream.BroadcastID, time.Now())
+			}
 			streamer.Mu.Unlock()
 
 			streamer.Mu.RLock()
@@ -147,6 +157,8 @@ func (m *Miner) handleStreamUp(ctx context.Context, streamer *model.Streamer) {
 	category := streamer.ResolveCategory()
 	streamer.Mu.Unlock()
 
+	m.twitch.InvalidateStreamInfo(username)
+
 	m.log.Event(ctx, model.EventStreamerOnline,
 		"Stream online",
 		"streamer", username,
@@ -159,15 +171,26 @@ func (m *Miner) handleStreamDown(ctx context.Context, streamer *model.Streamer)
 	streamer.Mu.Lock()
 	wasOnline := streamer.IsOnline
 	streamer.SetOffline()
+	now := time.Now()
+	session := streamer.EndSession(now)
 	username := streamer.Username
 	streamer.Mu.Unlock()
 
+	m.twitch.InvalidateStreamInfo(username)
+
 	if wasOnline {
 		m.log.Event(ctx, model.EventStreamerOffline,
 			"Stream went offline",
 			"streamer", username)
 	}
 
+	if session != nil {
+		m.log.Event(ctx, model.EventSessionSummary,
+			session.Summary(now),
+			"streamer", username,
+			"broadcast_id", session.BroadcastID)
+	}
+
 	m.updateChatPresence(streamer, false)
 }
 
@@ -216,30 +239,84 @@ func (m *Miner) handleRaid(ctx context.Context, msg *model.Message, streamer *mo
 
 	raidID, _ := raidData["id"].(string)
 	targetLogin, _ := raidData["target_login"].(string)
+	raiders, _ := raidData["viewer_count"].(float64)
 
 	if raidID == "" {
 		return
 	}
 
+	// raid_update_v2 repeats during the raid countdown; decide only once.
+	raid := model.NewRaid(raidID, targetLogin)
+	streamer.Mu.Lock()
+	seen := raid.Equal(streamer.Raid)
+	streamer.Raid = raid
+	streamer.Mu.Unlock()
+	if seen {
+		return
+	}
+
+	join, reason := m.checkRaid(ctx, streamer, targetLogin)
+	if !join {
+		m.log.Event(ctx, model.EventRaidSkipped,
+			"Skipping raid",
+			"streamer", username,
+			"target", targetLogin,
+			"raiders", int(raiders),
+			"reason", reason)
+		return
+	}
+
 	m.log.Event(ctx, model.EventJoinRaid,
 		"Joining raid",
 		"streamer", username,
-		"target", targetLogin)
-
-	streamer.Mu.Lock()
-	streamer.Raid = &model.Raid{
-		RaidID:      raidID,
-		TargetLogin: targetLogin,
-	}
-	streamer.Mu.Unlock()
+		"target", targetLogin,
+		"raiders", int(raiders),
+		"reason", reason)
 
 	if err := m.twitch.JoinRaid(ctx, raidID); err != nil {
 		m.log.Warn("Failed to join raid",
 			"streamer", username, "raid_id", raidID, "error", err)
+		streamer.Mu.Lock()
+		streamer.Raid = nil // retry on the next raid update
+		streamer.Mu.Unlock()
+		return
 	}
+
+	m.followRaidTarget(ctx, username, targetLogin)
 }
 
 
+// checkRaid decides whether streamer joins a raid into target. Raids into
+// blacklisted channels are never joined; otherwise the streamer's raid
+// filter decides, looking up the target's stream when the filter needs it.
+func (m *Miner) checkRaid(ctx context.Context, streamer *model.Streamer, target string) (bool, string) {
+	if m.isBlacklisted(target) {
+		return false, "target is blacklisted"
+	}
+
+	streamer.Mu.RLock()
+	var filter *model.RaidFilter
+	if streamer.Settings != nil {
+		filter = streamer.Settings.RaidFilter
+	}
+	streamer.Mu.RUnlock()
+
+	var game *model.GameInfo
+	viewers := 0
+	if filter.NeedsStream() {
+		info, err := m.twitch.GetStreamInfo(ctx, target)
+		if err != nil {
+			return false, fmt.Sprintf("target stream unavailable: %v", err)
+		}
+		if info == nil {
+			return false, "target is offline"
+		}
+		game = info.Game
+		viewers = info.ViewersCount
+	}
+	return filter.Check(target, game, viewers)
+}
+
 func (m *Miner) handleCommunityMoments(ctx context.Context, msg *model.Message, streamer *model.Streamer) {
 	if streamer == nil || msg.Data == nil {
 		return
@@ -275,6 +352,73 @@ func (m *Miner) handleCommunityMoments(ctx context.Context, msg *model.Message,
 }
 
 
+// handleHypeTrain emits an event when a hype train starts or reaches a new
+// level on a watched channel.
+func (m *Miner) handleHypeTrain(ctx context.Context, msg *model.Message, streamer *model.Streamer) {
+	if streamer == nil || msg.Data == nil {
+		return
+	}
+
+	streamer.Mu.RLock()
+	username := streamer.Username
+	streamer.Mu.RUnlock()
+
+	level := max(extractNestedInt(msg.Data, "progress", "level", "value"), 1)
+	progress := extractNestedInt(msg.Data, "progress", "value")
+	goal := extractNestedInt(msg.Data, "progress", "goal")
+
+	switch msg.Type {
+	case model.MsgTypeHypeTrainStart:
+		m.log.Event(ctx, model.EventHypeTrainStart,
+			"Hype train started",
+			"streamer", username,
+			"level", level,
+			"progress", fmt.Sprintf("%d/%d", progress, goal))
+	case model.MsgTypeHypeTrainLevelUp:
+		m.log.Event(ctx, model.EventHypeTrainLevel,
+			"Hype train level up",
+			"streamer", username,
+			"level", level,
+			"progress", fmt.Sprintf("%d/%d", progress, goal))
+	default:
+		m.log.Debug("Unhandled hype train message type", "type", string(msg.Type))
+	}
+}
+
+// handlePolls emits an event when a poll starts on a watched channel.
+func (m *Miner) handlePolls(ctx context.Context, msg *model.Message, streamer *model.Streamer) {
+	if streamer == nil || msg.Data == nil || msg.Type != model.MsgTypePollCreate {
+		return
+	}
+
+	poll, _ := msg.Data["poll"].(map[string]any)
+	if poll == nil {
+		return
+	}
+
+	title, _ := poll["title"].(string)
+	var choices []string
+	if rawChoices, ok := poll["choices"].([]any); ok {
+		for _, raw := range rawChoices {
+			choice, _ := raw.(map[string]any)
+			if choiceTitle, ok := choice["title"].(string); ok {
+				choices = append(choices, choiceTitle)
+			}
+		}
+	}
+
+	streamer.Mu.RLock()
+	username := streamer.Username
+	streamer.Mu.RUnlock()
+
+	m.log.Event(ctx, model.EventPollStart,
+		"Poll started",
+		"streamer", username,
+		"title", title,
+		"choices", strings.Join(choices, " | "),
+		"duration", time.Duration(jsonutil.IntFromAny(poll["duration_seconds"]))*time.Second)
+}
+
 func (m *Miner) handleCommunityGoals(_ context.Context, msg *model.Message, streamer *model.Streamer) {
 	if streamer == nil || msg.Data == nil {
 		return
@@ -316,6 +460,65 @@ func (m *Miner) handleCommunityGoals(_ context.Context, msg *model.Message, stre
 	}
 }
 
+func (m *Miner) handleDropEvents(ctx context.Context, msg *model.Message) {
+	if msg.Data == nil {
+		return
+	}
+
+	dropID, _ := msg.Data["drop_id"].(string)
+	if dropID == "" {
+		return
+	}
+
+	switch msg.Type {
+	case model.MsgTypeDropProgress:
+		current := jsonutil.IntFromAny(msg.Data["current_progress_min"])
+		required := jsonutil.IntFromAny(msg.Data["required_progress_min"])
+		m.twitch.UpdateDropProgress(dropID, current, required)
+		m.updateStreamerDrops(dropID, func(campaign *model.Campaign) {
+			if drop := campaign.FindDrop(dropID); drop != nil {
+				drop.SetProgress(current, required)
+			}
+		})
+
+	case model.MsgTypeDropClaim:
+		dropInstanceID, _ := msg.Data["drop_instance_id"].(string)
+		if dropInstanceID == "" {
+			return
+		}
+		if err := m.twitch.ClaimDrop(ctx, dropInstanceID); err != nil {
+			m.log.Warn("Failed to claim drop", "drop_id", dropID, "error", err)
+			return
+		}
+
+		name := dropID
+		if drop, ok := m.twitch.MarkDropClaimed(dropID); ok {
+			name = drop.String()
+		}
+		m.updateStreamerDrops(dropID, func(campaign *model.Campaign) {
+			campaign.MarkDropClaimed(dropID)
+		})
+
+		m.log.Event(ctx, model.EventDropClaim, "Drop claimed", "drop", name)
+	}
+}
+
+// updateStreamerDrops applies fn to every streamer campaign that contains
+// the unclaimed drop.
+func (m *Miner) updateStreamerDrops(dropID string, fn func(*model.Campaign)) {
+	for _, s := range m.getStreamers() {
+		s.Mu.Lock()
+		if s.Stream != nil {
+			for i := range s.Stream.Campaigns {
+				if s.Stream.Campaigns[i].FindDrop(dropID) != nil {
+					fn(&s.Stream.Campaigns[i])
+				}
+			}
+		}
+		s.Mu.Unlock()
+	}
+}
+
 func (m *Miner) updateChatPresence(streamer *model.Streamer, isOnline bool) {
 	streamer.Mu.RLock()
 	chatPresence := model.ChatNever
@@ -15,6 +15,7 @@ import (
 	"github.com/Guliveer/twitch-miner-go/internal/chat"
 	"github.com/Guliveer/twitch-miner-go/internal/config"
 	"github.com/Guliveer/twitch-miner-go/internal/constants"
+	"github.com/Guliveer/twitch-miner-go/internal/ledger"
 	"github.com/Guliveer/twitch-miner-go/internal/logger"
 	"github.com/Guliveer/twitch-miner-go/internal/model"
 	"github.com/Guliveer/twitch-miner-go/internal/notify"
@@ -33,10 +34,12 @@ type Miner struct {
 	pubsub *pubsub.Pool
 	chat   *chat.Manager
 	notify *notify.Dispatcher
+	ledger *ledger.Ledger
 
 	running atomic.Bool
 
-	catWatcher *watcher.CategoryWatcher
+	catWatcher   *watcher.CategoryWatcher
+	dropsWatcher *watcher.DropsWatcher
 
 	streamers   []*model.Streamer
 	streamersMu sync.RWMutex
@@ -51,6 +54,17 @@ type Miner struct {
 
 	lastWatching   map[string]bool
 	lastWatchingMu sync.Mutex
+
+	raidTargetMu sync.Mutex // serializes following raid targets
+
+	followed map[string]bool // logins followed at the last followers load
+
+	savedStreaks map[string]model.StreakState // last persisted watch streaks
+	streaksMu    sync.Mutex
+	streakAlerts map[string]string // streamer → broadcast ID alerted as at risk
+
+	savedGoals map[string]map[string]int // last persisted goal contributions
+	goalsMu    sync.Mutex
 }
 
 // NewMiner creates a new Miner from account configuration.
@@ -62,6 +76,7 @@ func NewMiner(cfg *config.AccountConfig, log *logger.Logger) *Miner {
 		pendingTimers:     make(map[string]*time.Timer),
 		priorities:        cfg.ParsedPriorities(),
 		lastWatching:      make(map[string]bool),
+		streakAlerts:      make(map[string]string),
 	}
 }
 
@@ -77,6 +92,27 @@ func (m *Miner) NotifyDispatcher() *notify.Dispatcher {
 	return m.notify
 }
 
+// Campaigns returns the active drop campaigns from the latest campaign sync.
+// Returns nil if the miner hasn't been started yet.
+func (m *Miner) Campaigns() []*model.Campaign {
+	if m.twitch == nil {
+		return nil
+	}
+	return m.twitch.Campaigns()
+}
+
+// Watching returns the usernames of the streamers currently receiving
+// minute-watched events.
+func (m *Miner) Watching() []string {
+	m.lastWatchingMu.Lock()
+	defer m.lastWatchingMu.Unlock()
+	watching := make([]string, 0, len(m.lastWatching))
+	for username := range m.lastWatching {
+		watching = append(watching, username)
+	}
+	return watching
+}
+
 // IsRunning reports whether the miner is currently running its main loop.
 func (m *Miner) IsRunning() bool {
 	return m.running.Load()
@@ -116,6 +152,12 @@ func (m *Miner) Run(ctx context.Context) error {
 	}
 	m.log.Info("🔑 Logged in successfully", "account", m.cfg.Username)
 
+	predictionLedger, err := ledger.Open(ledger.Path(m.cfg.Username))
+	if err != nil {
+		m.log.Warn("Failed to load prediction ledger, starting empty", "error", err)
+	}
+	m.ledger = predictionLedger
+
 	if m.cfg.Features.ClaimDropsStartup {
 		m.log.Info("🎯 Claiming pending drops from inventory on startup")
 		if err := m.twitch.ClaimAllDropsFromInventory(ctx); err != nil {
@@ -128,6 +170,8 @@ func (m *Miner) Run(ctx context.Context) error {
 		m.twitch.GQLClient().SetNormalMode()
 		return fmt.Errorf("resolving streamers: %w", err)
 	}
+	m.loadStreaks()
+	m.loadGoalContributions()
 
 	m.notify = notify.NewDispatcher(m.cfg.Notifications, m.log)
 	m.log.SetNotifyFunc(m.notify.NotifyFunc(m.cfg.Username))
@@ -175,7 +219,11 @@ func (m *Miner) Run(ctx context.Context) error {
 		return m.runContextRefresh(ctx)
 	})
 
-	if m.cfg.CategoryWatcher.Enabled && len(m.cfg.CategoryWatcher.Categories) > 0 {
+	g.Go(func() error {
+		return m.runFollowersRefresh(ctx)
+	})
+
+	if m.cfg.CategoryWatcher.Enabled && (len(m.cfg.CategoryWatcher.Categories) > 0 || m.cfg.CategoryWatcher.AutoDropsCategories) {
 		defaults := m.getStreamerDefaults()
 		m.catWatcher = watcher.NewCategoryWatcher(
 			m.cfg.CategoryWatcher,
@@ -183,12 +231,30 @@ func (m *Miner) Run(ctx context.Context) error {
 			m.log,
 			m.cfg.Blacklist,
 			defaults,
+			m.twitch.Campaigns,
 		)
 		g.Go(func() error {
 			return m.catWatcher.Run(ctx, m.addStreamer, m.removeStreamerWithReason, m.getStreamers)
 		})
 	}
 
+	if m.cfg.Drops.Watcher.Enabled {
+		m.dropsWatcher = watcher.NewDropsWatcher(
+			m.cfg.Drops.Watcher,
+			m.twitch.GQLClient(),
+			m.log,
+			m.cfg.Blacklist,
+			m.getStreamerDefaults(),
+			m.twitch.Campaigns,
+			m.watchSelection,
+			m.twitch.GetStreamInfo,
+			m.twitch.GetChannelID,
+		)
+		g.Go(func() error {
+			return m.dropsWatcher.Run(ctx, m.addStreamer, m.removeStreamerWithReason, m.getStreamers)
+		})
+	}
+
 	g.Go(func() error {
 		return m.runMonitorLoop(ctx)
 	})
@@ -204,6 +270,9 @@ func (m *Miner) Run(ctx context.Context) error {
 
 	err = g.Wait()
 
+	m.saveStreaks()
+	m.saveGoalContributions()
+
 	m.pendingTimersMu.Lock()
 	for id, t := range m.pendingTimers {
 		t.Stop()
@@ -346,6 +415,10 @@ func (m *Miner) subscribeAllTopics(ctx context.Context) error {
 		}
 	}
 
+	if m.dropsEnabled() {
+		topics = append(topics, model.NewUserTopic(model.PubSubTopicDropEvents, userID))
+	}
+
 	for _, s := range streamers {
 		topics = append(topics, m.streamerTopics(s)...)
 	}
@@ -377,6 +450,12 @@ func (m *Miner) streamerTopics(s *model.Streamer) []*model.PubSubTopic {
 	if s.Settings.CommunityGoalsEnabled {
 		topics = append(topics, model.NewStreamerTopic(model.PubSubTopicCommunityGoals, s))
 	}
+	if s.Settings.HypeTrain {
+		topics = append(topics, model.NewStreamerTopic(model.PubSubTopicHypeTrain, s))
+	}
+	if s.Settings.Polls {
+		topics = append(topics, model.NewStreamerTopic(model.PubSubTopicPolls, s))
+	}
 
 	return topics
 }
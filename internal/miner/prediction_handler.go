@@ -2,11 +2,15 @@ package miner
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/Guliveer/twitch-miner-go/internal/constants"
 	"github.com/Guliveer/twitch-miner-go/internal/jsonutil"
+	"github.com/Guliveer/twitch-miner-go/internal/ledger"
 	"github.com/Guliveer/twitch-miner-go/internal/model"
+	"github.com/Guliveer/twitch-miner-go/internal/twitch"
 )
 
 
@@ -29,7 +33,7 @@ func (m *Miner) handlePredictionsChannel(ctx context.Context, msg *model.Message
 	case model.MsgTypePredictionUpdate:
 		m.handlePredictionUpdated(eventDict, eventID, eventStatus)
 	case model.MsgTypePredictionLocked:
-		m.handlePredictionLocked(eventID, eventStatus)
+		m.handlePredictionLocked(ctx, streamer, eventID, eventStatus)
 	}
 }
 
@@ -57,6 +61,7 @@ func (m *Miner) handlePredictionCreated(
 	balance := streamer.ChannelPoints
 	betSettings := streamer.Settings.Bet
 	username := streamer.Username
+	onlineAt := streamer.OnlineAt
 	streamer.Mu.RUnlock()
 
 	if !makePredictions || !isOnline {
@@ -84,6 +89,28 @@ func (m *Miner) handlePredictionCreated(
 		eventStatus,
 		outcomes,
 	)
+	event.LocksAt = createdAt.Add(time.Duration(predictionWindowSeconds * float64(time.Second)))
+
+	if rule, forced := betSettings.MatchTitleRule(event.Title, outcomes); rule != nil {
+		if rule.Skip {
+			m.log.Event(ctx, model.EventBetFilters, "Skip betting for event",
+				"streamer", username,
+				"title", event.Title,
+				"rule", rule.Name)
+			m.recordSkippedPrediction(event, username, rule.Name)
+			return
+		}
+		event.ApplyTitleRule(rule, forced)
+		// Later checks use the settings with the rule's overrides applied.
+		betSettings = event.Bet.Settings
+		m.log.Debug("Prediction title rule applied",
+			"streamer", username, "event_id", eventID, "rule", rule.Name)
+	}
+
+	event.Bet.AccountBalanceFloor = m.accountBankroll().BalanceFloor
+	if betSettings.Sizing == model.SizingKelly {
+		event.Bet.SetWinRates(m.ledger.WinRates(username, len(outcomes)))
+	}
 
 	secondsUntilClose := event.ClosingBetAfter(msg.Timestamp)
 	if secondsUntilClose <= 0 {
@@ -101,6 +128,15 @@ func (m *Miner) handlePredictionCreated(
 		return
 	}
 
+	if reason := m.bankrollBlockReason(username, onlineAt, balance, betSettings.Bankroll); reason != "" {
+		m.log.Event(ctx, model.EventBetFilters,
+			"Bankroll rule prevented bet",
+			"streamer", username,
+			"title", event.Title,
+			"reason", reason)
+		return
+	}
+
 	m.eventsPredictionsMu.Lock()
 	m.eventsPredictions[eventID] = event
 	m.eventsPredictionsMu.Unlock()
@@ -125,7 +161,12 @@ func (m *Miner) handlePredictionCreated(
 		if err := m.twitch.MakePrediction(ctx, streamer, prediction); err != nil {
 			m.log.Warn("Failed to place prediction",
 				"streamer", username, "event_id", eventID, "error", err)
+			if errors.Is(err, twitch.ErrBetMayHaveLanded) {
+				m.scheduleBetConfirmation(ctx, prediction, username)
+			}
+			return
 		}
+		m.scheduleBetConfirmation(ctx, prediction, username)
 	})
 
 	m.pendingTimersMu.Lock()
@@ -133,7 +174,38 @@ func (m *Miner) handlePredictionCreated(
 	m.pendingTimersMu.Unlock()
 }
 
+// recordSkippedPrediction records in the ledger that a title rule skipped
+// the prediction.
+func (m *Miner) recordSkippedPrediction(event *model.EventPrediction, username, rule string) {
+	entry := ledger.Entry{
+		EventID:    event.EventID,
+		Streamer:   username,
+		Title:      event.Title,
+		Outcomes:   append([]model.Outcome(nil), event.Bet.Outcomes...),
+		Strategy:   event.Bet.Settings.Strategy.String(),
+		Sizing:     event.Bet.Settings.Sizing.String(),
+		Decision:   model.BetDecision{Choice: -1},
+		Rule:       rule,
+		Result:     ledger.ResultSkipped,
+		CreatedAt:  event.CreatedAt,
+		ResolvedAt: time.Now(),
+	}
+	if err := m.ledger.Record(entry); err != nil {
+		m.log.Warn("Failed to record prediction in ledger",
+			"streamer", username, "event_id", entry.EventID, "error", err)
+	}
+}
+
 func (m *Miner) handlePredictionUpdated(eventDict map[string]any, eventID, eventStatus string) {
+	// The resolved event may arrive after our own result was recorded and the
+	// prediction dropped from the map, so patch the ledger by event ID.
+	winningOutcomeID := jsonutil.StringFromAny(eventDict["winning_outcome_id"])
+	if eventStatus == "RESOLVED" && winningOutcomeID != "" {
+		if err := m.ledger.SetWinner(eventID, winningOutcomeID); err != nil {
+			m.log.Warn("Failed to update prediction ledger", "event_id", eventID, "error", err)
+		}
+	}
+
 	m.eventsPredictionsMu.RLock()
 	event, ok := m.eventsPredictions[eventID]
 	m.eventsPredictionsMu.RUnlock()
@@ -145,14 +217,19 @@ func (m *Miner) handlePredictionUpdated(eventDict map[string]any, eventID, event
 	defer event.Mu.Unlock()
 
 	event.Status = eventStatus
+	if winningOutcomeID != "" {
+		event.WinningOutcomeID = winningOutcomeID
+	}
 
-	if !event.BetPlaced && event.Bet.Decision.Choice == -1 {
+	// Keep the outcomes current until the bet is placed so it is calculated
+	// on the latest odds.
+	if !event.BetPlaced {
 		outcomes := parseOutcomes(eventDict["outcomes"])
 		event.Bet.UpdateOutcomes(outcomes)
 	}
 }
 
-func (m *Miner) handlePredictionLocked(eventID, eventStatus string) {
+func (m *Miner) handlePredictionLocked(ctx context.Context, streamer *model.Streamer, eventID, eventStatus string) {
 	m.eventsPredictionsMu.RLock()
 	event, ok := m.eventsPredictions[eventID]
 	m.eventsPredictionsMu.RUnlock()
@@ -161,10 +238,31 @@ func (m *Miner) handlePredictionLocked(eventID, eventStatus string) {
 	}
 
 	event.Mu.Lock()
+	alreadyLocked := event.Status == eventStatus
 	event.Status = eventStatus
+	title := event.Title
+	betPlaced := event.BetPlaced
+	amount := event.Bet.Decision.Amount
 	event.Mu.Unlock()
 
-	m.log.Debug("Prediction locked", "event_id", eventID)
+	if alreadyLocked {
+		return
+	}
+
+	streamer.Mu.RLock()
+	username := streamer.Username
+	streamer.Mu.RUnlock()
+
+	if betPlaced {
+		m.log.Event(ctx, model.EventBetLocked, "Prediction locked",
+			"streamer", username,
+			"title", title,
+			"amount", amount)
+		return
+	}
+	m.log.Event(ctx, model.EventBetLocked, "Prediction locked without a bet",
+		"streamer", username,
+		"title", title)
 }
 
 
@@ -234,6 +332,25 @@ func (m *Miner) handlePredictionResult(ctx context.Context, event *model.EventPr
 	}
 	eventTitle := event.Title
 	resultString := event.Result.ResultString
+	broadcastID := event.BroadcastID
+	entry := ledger.Entry{
+		EventID:          event.EventID,
+		Title:            event.Title,
+		Outcomes:         append([]model.Outcome(nil), event.Bet.Outcomes...),
+		Balance:          event.Bet.Balance,
+		PredictionWindow: event.PredictionWindowSeconds,
+		Strategy:         event.Bet.Settings.Strategy.String(),
+		Sizing:           event.Bet.Settings.Sizing.String(),
+		Decision:         event.Bet.Decision,
+		Rule:             event.Rule,
+		Result:           resultType,
+		Placed:           points["placed"],
+		Won:              points["won"],
+		Gained:           points["gained"],
+		WinningOutcomeID: event.WinningOutcomeID,
+		CreatedAt:        event.CreatedAt,
+		ResolvedAt:       time.Now(),
+	}
 	event.Mu.Unlock()
 
 	m.pendingTimersMu.Lock()
@@ -250,6 +367,12 @@ func (m *Miner) handlePredictionResult(ctx context.Context, event *model.EventPr
 		streamer.Mu.RUnlock()
 	}
 
+	entry.Streamer = streamerName
+	if err := m.ledger.Record(entry); err != nil {
+		m.log.Warn("Failed to record prediction in ledger",
+			"streamer", streamerName, "event_id", entry.EventID, "error", err)
+	}
+
 	m.log.Event(ctx, notifyEvent,
 		"Prediction result",
 		"streamer", streamerName,
@@ -265,6 +388,11 @@ func (m *Miner) handlePredictionResult(ctx context.Context, event *model.EventPr
 			streamer.UpdateHistory("REFUND", -points["placed"], -1)
 		} else if resultType == "WIN" {
 			streamer.UpdateHistory("PREDICTION", -points["won"], -1)
+			// Credit the session the bet was placed in, even if that
+			// stream has ended by the time the prediction resolves.
+			if session := streamer.SessionFor(broadcastID); session != nil {
+				session.BetsWon++
+			}
 		}
 		streamer.Mu.Unlock()
 	}
@@ -279,5 +407,48 @@ func (m *Miner) handlePredictionMade(event *model.EventPrediction) {
 	event.Mu.Lock()
 	event.BetConfirmed = true
 	event.Mu.Unlock()
+
+	m.pendingTimersMu.Lock()
+	if t, ok := m.pendingTimers[event.EventID]; ok {
+		t.Stop()
+		delete(m.pendingTimers, event.EventID)
+	}
+	m.pendingTimersMu.Unlock()
+
 	m.log.Debug("Prediction confirmed", "event_id", event.EventID)
 }
+
+// scheduleBetConfirmation waits for the prediction-made message of a placed
+// bet. If it has not arrived shortly after the prediction locks, the bet is
+// reported as unconfirmed since its result will not be tracked.
+func (m *Miner) scheduleBetConfirmation(ctx context.Context, event *model.EventPrediction, username string) {
+	event.Mu.Lock()
+	eventID := event.EventID
+	title := event.Title
+	wait := max(time.Until(event.LocksAt), 0) + constants.PredictionConfirmGrace
+	event.Mu.Unlock()
+
+	confirmTimer := time.AfterFunc(wait, func() {
+		m.pendingTimersMu.Lock()
+		delete(m.pendingTimers, eventID)
+		m.pendingTimersMu.Unlock()
+
+		event.Mu.Lock()
+		confirmed := event.BetConfirmed
+		amount := event.Bet.Decision.Amount
+		event.Mu.Unlock()
+		if confirmed {
+			return
+		}
+
+		m.log.Event(ctx, model.EventBetUnconfirmed,
+			"Bet was not confirmed before the prediction locked",
+			"streamer", username,
+			"title", title,
+			"amount", amount)
+	})
+
+	m.pendingTimersMu.Lock()
+	m.pendingTimers[eventID] = confirmTimer
+	m.pendingTimersMu.Unlock()
+}
@@ -0,0 +1,106 @@
+package miner
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/Guliveer/twitch-miner-go/internal/model"
+)
+
+// followRaidTarget adds the target of a joined raid as a temporary streamer
+// when follow_raid_target is enabled. Blacklisted and already tracked targets
+// are skipped. The target is removed by the monitor loop once its TTL expires
+// or it goes offline.
+func (m *Miner) followRaidTarget(ctx context.Context, raider, targetLogin string) {
+	cfg := m.cfg.FollowRaidTarget
+	target := strings.ToLower(strings.TrimSpace(targetLogin))
+	if !cfg.Enabled || target == "" {
+		return
+	}
+
+	if m.isBlacklisted(target) {
+		m.log.Debug("Raid target is blacklisted, not following",
+			"streamer", raider, "target", target)
+		return
+	}
+
+	// Serialize so that two raids on the same target add it only once.
+	m.raidTargetMu.Lock()
+	defer m.raidTargetMu.Unlock()
+
+	for _, s := range m.getStreamers() {
+		if strings.EqualFold(s.Username, target) {
+			return
+		}
+	}
+
+	channelID, err := m.twitch.GetChannelID(ctx, target)
+	if err != nil || channelID == "" {
+		m.log.Warn("Failed to resolve raid target",
+			"streamer", raider, "target", target, "error", err)
+		return
+	}
+
+	defaults := m.getStreamerDefaults()
+	defaults.FollowRaid = false
+
+	streamer := model.NewStreamer(target)
+	streamer.ChannelID = channelID
+	streamer.AccountUsername = m.cfg.Username
+	streamer.Settings = cfg.Settings.ToStreamerSettings(defaults)
+	streamer.RaidFrom = raider
+	streamer.RaidTargetExpiresAt = time.Now().Add(cfg.TTL)
+
+	if err := m.twitch.CheckStreamerOnline(ctx, streamer); err != nil {
+		m.log.Debug("Online check failed for raid target",
+			"streamer", target, "error", err)
+	}
+	streamer.Mu.RLock()
+	online := streamer.IsOnline
+	streamer.Mu.RUnlock()
+	if !online {
+		m.log.Debug("Raid target is offline, not following",
+			"streamer", raider, "target", target)
+		return
+	}
+
+	if err := m.twitch.LoadChannelPointsContext(ctx, streamer); err != nil {
+		m.log.Warn("Failed to load channel points context",
+			"streamer", target, "error", err)
+	}
+
+	if !m.addStreamer(ctx, streamer) {
+		m.log.Debug("Raid target is already tracked, not following",
+			"streamer", raider, "target", target)
+		return
+	}
+	m.updateChatPresence(streamer, true)
+
+	m.log.Info("🎯 Following raid target",
+		"streamer", target,
+		"raid_from", raider,
+		"ttl", cfg.TTL,
+	)
+}
+
+// expireRaidTarget removes a followed raid target once its TTL has passed
+// or it has gone offline. Other streamers are left untouched.
+func (m *Miner) expireRaidTarget(s *model.Streamer, now time.Time) {
+	s.Mu.RLock()
+	isRaidTarget := s.RaidFrom != ""
+	online := s.IsOnline
+	expired := now.After(s.RaidTargetExpiresAt)
+	username := s.Username
+	s.Mu.RUnlock()
+
+	if !isRaidTarget {
+		return
+	}
+	switch {
+	case !online:
+		m.removeStreamerWithReason(username, "raid target offline")
+	case expired:
+		m.removeStreamerWithReason(username, "raid target expired")
+	}
+}
@@ -21,9 +21,10 @@ func (m *Miner) runMinuteWatcher(ctx context.Context) error {
 			return ctx.Err()
 		case <-ticker.C:
 			streamers := m.getStreamers()
-			toWatch := twitch.SelectStreamersToWatch(streamers, m.priorities, constants.MaxWatchStreams)
+			toWatch := m.selectToWatch(streamers)
 
 			m.logWatchingChanges(toWatch)
+			m.checkStreaksAtRisk(ctx, streamers, toWatch)
 
 			if len(toWatch) > 0 {
 				if err := m.twitch.SendMinuteWatchedEvents(ctx, toWatch); err != nil {
@@ -37,6 +38,17 @@ func (m *Miner) runMinuteWatcher(ctx context.Context) error {
 	}
 }
 
+// selectToWatch returns the streamers that get minute-watched events.
+func (m *Miner) selectToWatch(streamers []*model.Streamer) []*model.Streamer {
+	return twitch.SelectStreamersToWatch(streamers, m.priorities, constants.MaxWatchStreams)
+}
+
+// watchSelection returns the streamers that would get minute-watched events
+// now.
+func (m *Miner) watchSelection() []*model.Streamer {
+	return m.selectToWatch(m.getStreamers())
+}
+
 // logWatchingChanges compares the current set of watched streamers with the
 func (m *Miner) logWatchingChanges(toWatch []*model.Streamer) {
 	currentSet := make(map[string]bool, len(toWatch))
@@ -64,19 +76,25 @@ func (m *Miner) logWatchingChanges(toWatch []*model.Streamer) {
 	m.lastWatching = currentSet
 }
 
-func (m *Miner) runCampaignSync(ctx context.Context) error {
-	hasDrops := false
+// dropsEnabled reports whether any streamer claims drops or a watcher needs
+// the drop campaigns.
+func (m *Miner) dropsEnabled() bool {
+	if m.cfg.Drops.Watcher.Enabled || m.cfg.CategoryWatcher.Enabled && m.cfg.CategoryWatcher.AutoDropsCategories {
+		return true
+	}
 	for _, s := range m.getStreamers() {
 		s.Mu.RLock()
-		if s.Settings != nil && s.Settings.ClaimDrops {
-			hasDrops = true
-		}
+		claimDrops := s.Settings != nil && s.Settings.ClaimDrops
 		s.Mu.RUnlock()
-		if hasDrops {
-			break
+		if claimDrops {
+			return true
 		}
 	}
-	if !hasDrops {
+	return false
+}
+
+func (m *Miner) runCampaignSync(ctx context.Context) error {
+	if !m.dropsEnabled() {
 		<-ctx.Done()
 		return ctx.Err()
 	}
@@ -158,7 +176,10 @@ func (m *Miner) runMonitorLoop(ctx context.Context) error {
 					m.log.Debug("Online check failed",
 						"streamer", s.Username, "error", err)
 				}
+				m.expireRaidTarget(s, time.Now())
 			}
+			m.saveStreaks()
+			m.saveGoalContributions()
 		}
 	}
 }
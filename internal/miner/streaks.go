@@ -0,0 +1,116 @@
+package miner
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"os"
+	"strings"
+
+	"github.com/Guliveer/twitch-miner-go/internal/model"
+	"github.com/Guliveer/twitch-miner-go/internal/streaks"
+)
+
+// loadStreaks reads the persisted watch streaks of the account and applies
+// them to the tracked streamers.
+func (m *Miner) loadStreaks() {
+	states, err := streaks.Load(streaks.Path(m.cfg.Username))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		m.log.Warn("Failed to load watch streaks, starting empty", "error", err)
+	}
+	if states == nil {
+		states = make(map[string]model.StreakState)
+	}
+
+	m.streaksMu.Lock()
+	m.savedStreaks = states
+	m.streaksMu.Unlock()
+
+	for _, s := range m.getStreamers() {
+		m.restoreStreak(s)
+	}
+}
+
+// restoreStreak applies the persisted watch streak of a streamer, if any.
+func (m *Miner) restoreStreak(s *model.Streamer) {
+	m.streaksMu.Lock()
+	state, ok := m.savedStreaks[strings.ToLower(s.Username)]
+	m.streaksMu.Unlock()
+	if !ok {
+		return
+	}
+
+	s.Mu.Lock()
+	s.Streak = state
+	s.Mu.Unlock()
+}
+
+// saveStreaks persists the watch streaks of the tracked streamers if any
+// changed since the last save. States of streamers that are no longer
+// tracked are kept.
+func (m *Miner) saveStreaks() {
+	m.streaksMu.Lock()
+	defer m.streaksMu.Unlock()
+
+	if m.savedStreaks == nil {
+		return
+	}
+
+	states := maps.Clone(m.savedStreaks)
+	for _, s := range m.getStreamers() {
+		s.Mu.RLock()
+		if !s.Streak.IsZero() {
+			states[strings.ToLower(s.Username)] = s.Streak
+		}
+		s.Mu.RUnlock()
+	}
+	if maps.Equal(states, m.savedStreaks) {
+		return
+	}
+
+	if err := streaks.Save(streaks.Path(m.cfg.Username), states); err != nil {
+		m.log.Warn("Failed to save watch streaks", "error", err)
+		return
+	}
+	m.savedStreaks = states
+}
+
+// checkStreaksAtRisk emits WATCH_STREAK_AT_RISK once per broadcast for a live
+// streamer with a running watch streak that still misses this broadcast's
+// streak bonus but did not get a watch slot. Only called from the minute
+// watcher goroutine.
+func (m *Miner) checkStreaksAtRisk(ctx context.Context, streamers, toWatch []*model.Streamer) {
+	watching := make(map[*model.Streamer]bool, len(toWatch))
+	for _, s := range toWatch {
+		watching[s] = true
+	}
+
+	for _, s := range streamers {
+		if watching[s] {
+			continue
+		}
+
+		s.Mu.RLock()
+		atRisk := s.IsOnline &&
+			s.Settings != nil && s.Settings.WatchStreak &&
+			s.Streak.Value > 0 &&
+			s.Stream != nil && s.Stream.WatchStreakMissing && s.Stream.BroadcastID != ""
+		username := s.Username
+		streak := s.Streak.Value
+		broadcastID := ""
+		if s.Stream != nil {
+			broadcastID = s.Stream.BroadcastID
+		}
+		s.Mu.RUnlock()
+
+		if !atRisk || m.streakAlerts[username] == broadcastID {
+			continue
+		}
+		m.streakAlerts[username] = broadcastID
+
+		m.log.Event(ctx, model.EventWatchStreakAtRisk,
+			"Watch streak at risk, no watch slot available",
+			"streamer", username,
+			"streak", streak)
+	}
+}
@@ -33,12 +33,35 @@ func (m *Miner) getStreamerByChannelID(channelID string) *model.Streamer {
 	return nil
 }
 
-// addStreamer adds a new streamer to the list and subscribes to its PubSub topics.
-func (m *Miner) addStreamer(ctx context.Context, s *model.Streamer) {
+// isBlacklisted reports whether username is on the account's blacklist.
+func (m *Miner) isBlacklisted(username string) bool {
+	for _, name := range m.cfg.Blacklist {
+		if strings.EqualFold(strings.TrimSpace(name), username) {
+			return true
+		}
+	}
+	return false
+}
+
+// addStreamer adds a new streamer to the list and subscribes to its PubSub
+// topics. It reports whether the streamer was added: a streamer whose
+// username is already tracked is not added again, so concurrent adds of the
+// same channel cannot duplicate it or its subscriptions.
+func (m *Miner) addStreamer(ctx context.Context, s *model.Streamer) bool {
 	if s.AccountUsername == "" {
 		s.AccountUsername = m.cfg.Username
 	}
+	m.restoreStreak(s)
+	m.restoreGoalContributions(s)
 	m.streamersMu.Lock()
+	for _, existing := range m.streamers {
+		if strings.EqualFold(existing.Username, s.Username) {
+			m.streamersMu.Unlock()
+			m.log.Debug("Streamer already tracked, not adding it again",
+				"streamer", s.Username)
+			return false
+		}
+	}
 	m.streamers = append(m.streamers, s)
 	m.streamersMu.Unlock()
 
@@ -48,12 +71,13 @@ func (m *Miner) addStreamer(ctx context.Context, s *model.Streamer) {
 			"streamer", s.Username, "error", err)
 	}
 
-	if !s.IsCategoryWatched {
+	if !s.IsCategoryWatched && s.DropsCampaignID == "" && s.RaidFrom == "" {
 		m.log.Info("➕ Added",
 			"streamer", s.Username,
 			"channel_id", s.ChannelID,
 		)
 	}
+	return true
 }
 
 func (m *Miner) removeStreamer(username string) {
@@ -98,6 +122,12 @@ func (m *Miner) removeStreamerWithReason(username string, reason string) {
 	if removed.CategorySlug != "" {
 		logFields = append(logFields, "category", removed.CategorySlug)
 	}
+	if removed.DropsCampaignID != "" {
+		logFields = append(logFields, "campaign_id", removed.DropsCampaignID)
+	}
+	if removed.RaidFrom != "" {
+		logFields = append(logFields, "raid_from", removed.RaidFrom)
+	}
 	removed.Mu.RUnlock()
 
 	m.log.Info("➖ Removed", logFields...)
@@ -126,7 +156,7 @@ func (m *Miner) resolveStreamers(ctx context.Context) error {
 	}
 
 	if m.cfg.Followers.Enabled {
-		followers, err := m.twitch.GetFollowers(ctx, 100, m.cfg.Followers.Order)
+		followers, err := m.loadFollows(ctx)
 		if err != nil {
 			m.log.Warn("Failed to load followers", "error", err)
 		} else {
@@ -135,10 +165,12 @@ func (m *Miner) resolveStreamers(ctx context.Context) error {
 			for _, u := range usernames {
 				existing[u] = true
 			}
-			for _, followerLogin := range followers {
-					followerLower := strings.ToLower(followerLogin)
-					if !existing[followerLower] && !blacklist[followerLower] {
-						usernames = append(usernames, followerLower)
+			m.followed = make(map[string]bool, len(followers))
+			for _, followerLower := range followers {
+				m.followed[followerLower] = true
+				if !existing[followerLower] && !blacklist[followerLower] {
+					usernames = append(usernames, followerLower)
+					existing[followerLower] = true
 				}
 			}
 		}
@@ -215,7 +247,7 @@ func (m *Miner) resolveStreamers(ctx context.Context) error {
 		}
 	}
 
-	if len(resolved) == 0 && !m.cfg.CategoryWatcher.Enabled {
+	if len(resolved) == 0 && !m.cfg.CategoryWatcher.Enabled && !m.cfg.Drops.Watcher.Enabled {
 		return fmt.Errorf("no streamers could be resolved for account %s", m.cfg.Username)
 	}
 
@@ -0,0 +1,29 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// BankrollSettings holds limits that stop betting to protect a balance.
+// A zero value disables the corresponding rule.
+type BankrollSettings struct {
+	// DailyLossLimit is the maximum net points lost over the last 24 hours.
+	DailyLossLimit int `json:"daily_loss_limit" yaml:"daily_loss_limit"`
+	// WeeklyLossLimit is the maximum net points lost over the last 7 days.
+	WeeklyLossLimit int `json:"weekly_loss_limit" yaml:"weekly_loss_limit"`
+	// MaxBetsPerStream is the maximum number of bets placed during one stream.
+	MaxBetsPerStream int `json:"max_bets_per_stream" yaml:"max_bets_per_stream"`
+	// LosingStreak pauses betting after this many consecutive losses.
+	LosingStreak int `json:"losing_streak" yaml:"losing_streak"`
+	// Cooldown is how long betting stays paused after a losing streak.
+	Cooldown time.Duration `json:"cooldown" yaml:"cooldown"`
+	// BalanceFloor is the balance a bet may never take the channel below.
+	BalanceFloor int `json:"balance_floor" yaml:"balance_floor"`
+}
+
+// String returns a human-readable representation of the bankroll settings.
+func (bs BankrollSettings) String() string {
+	return fmt.Sprintf("BankrollSettings(daily_loss_limit=%d, weekly_loss_limit=%d, max_bets_per_stream=%d, losing_streak=%d, cooldown=%s, balance_floor=%d)",
+		bs.DailyLossLimit, bs.WeeklyLossLimit, bs.MaxBetsPerStream, bs.LosingStreak, bs.Cooldown, bs.BalanceFloor)
+}
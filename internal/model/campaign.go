@@ -16,7 +16,9 @@ type Campaign struct {
 	StartAt time.Time `json:"start_at"`
 	IsWithinTimeWindow bool `json:"dt_match"`
 	Drops []*Drop `json:"drops,omitempty"`
+	ClaimedDrops []*Drop `json:"claimed_drops,omitempty"`
 	Channels []string `json:"channels,omitempty"`
+	ChannelLogins []string `json:"channel_logins,omitempty"` // Logins matching Channels by index
 }
 
 // NewCampaign creates a Campaign from raw API data.
@@ -36,16 +38,72 @@ func NewCampaign(id, name, status string, game *GameInfo, startAt, endAt time.Ti
 }
 
 // ClearDrops removes drops that are outside the time window or already claimed.
+// Claimed drops are moved to ClaimedDrops.
 func (c *Campaign) ClearDrops() {
 	filtered := make([]*Drop, 0, len(c.Drops))
 	for _, drop := range c.Drops {
-		if drop.IsWithinTimeWindow && !drop.IsClaimed {
+		if drop.IsClaimed {
+			c.ClaimedDrops = append(c.ClaimedDrops, drop)
+			continue
+		}
+		if drop.IsWithinTimeWindow {
 			filtered = append(filtered, drop)
 		}
 	}
 	c.Drops = filtered
 }
 
+// IsUnfinished reports whether the campaign is still running and has drops
+// left to earn. Call after ClearDrops so claimed drops are excluded.
+func (c *Campaign) IsUnfinished() bool {
+	return len(c.Drops) > 0 && time.Now().Before(c.EndAt)
+}
+
+// FindDrop returns the unclaimed drop with the given ID, or nil.
+func (c *Campaign) FindDrop(dropID string) *Drop {
+	for _, drop := range c.Drops {
+		if drop.ID == dropID {
+			return drop
+		}
+	}
+	return nil
+}
+
+// MarkDropClaimed flags the drop as claimed and moves it to ClaimedDrops.
+// Returns a copy of the drop, or false if the campaign has no such drop.
+func (c *Campaign) MarkDropClaimed(dropID string) (Drop, bool) {
+	drop := c.FindDrop(dropID)
+	if drop == nil {
+		return Drop{}, false
+	}
+	drop.IsClaimed = true
+	drop.IsClaimable = false
+	drop.PercentageProgress = 100
+	c.ClearDrops()
+	return *drop, true
+}
+
+// Clone returns a copy of the campaign whose drops can be updated without
+// affecting the original.
+func (c *Campaign) Clone() *Campaign {
+	clone := *c
+	clone.Drops = cloneDrops(c.Drops)
+	clone.ClaimedDrops = cloneDrops(c.ClaimedDrops)
+	return &clone
+}
+
+func cloneDrops(drops []*Drop) []*Drop {
+	if drops == nil {
+		return nil
+	}
+	cloned := make([]*Drop, len(drops))
+	for i, drop := range drops {
+		dropCopy := *drop
+		cloned[i] = &dropCopy
+	}
+	return cloned
+}
+
 // Equal returns true if two campaigns have the same ID.
 func (c *Campaign) Equal(other *Campaign) bool {
 	if other == nil {
@@ -0,0 +1,67 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// CategoryFilter decides which streams of a watched category are eligible.
+// Languages and tags are matched case-insensitively. Empty lists, nil
+// patterns and zero viewer bounds allow everything.
+type CategoryFilter struct {
+	Languages []string `json:"languages,omitempty"`
+	MinViewers int `json:"min_viewers,omitempty"`
+	MaxViewers int `json:"max_viewers,omitempty"`
+	RequiredTags []string `json:"required_tags,omitempty"`
+	ExcludedTags []string `json:"excluded_tags,omitempty"`
+	TitleInclude *regexp.Regexp `json:"-"`
+	TitleExclude *regexp.Regexp `json:"-"`
+	ExcludeMature bool `json:"exclude_mature,omitempty"`
+}
+
+// Check decides whether a category stream is eligible. It returns whether
+// the stream passes and, if not, the reason. A nil filter passes every
+// stream.
+func (f *CategoryFilter) Check(title, language string, tags []string, viewers int, mature bool) (bool, string) {
+	if f == nil {
+		return true, ""
+	}
+	if len(f.Languages) > 0 && !containsFold(f.Languages, language) {
+		if language == "" {
+			return false, "language unknown"
+		}
+		return false, fmt.Sprintf("language %s is not allowed", language)
+	}
+	if f.MinViewers > 0 && viewers < f.MinViewers {
+		return false, fmt.Sprintf("%d viewers, below %d", viewers, f.MinViewers)
+	}
+	if f.MaxViewers > 0 && viewers > f.MaxViewers {
+		return false, fmt.Sprintf("%d viewers, above %d", viewers, f.MaxViewers)
+	}
+	for _, tag := range f.RequiredTags {
+		if !containsFold(tags, tag) {
+			return false, fmt.Sprintf("tag %s missing", tag)
+		}
+	}
+	for _, tag := range f.ExcludedTags {
+		if containsFold(tags, tag) {
+			return false, fmt.Sprintf("tag %s is excluded", tag)
+		}
+	}
+	if f.TitleInclude != nil && !f.TitleInclude.MatchString(title) {
+		return false, "title does not match title_include"
+	}
+	if f.TitleExclude != nil && f.TitleExclude.MatchString(title) {
+		return false, "title matches title_exclude"
+	}
+	if f.ExcludeMature && mature {
+		return false, "stream is mature"
+	}
+	return true, ""
+}
+
+// String returns a human-readable representation of the category filter.
+func (f *CategoryFilter) String() string {
+	return fmt.Sprintf("CategoryFilter(languages=%v, min_viewers=%d, max_viewers=%d, required_tags=%v, excluded_tags=%v, title_include=%s, title_exclude=%s, exclude_mature=%t)",
+		f.Languages, f.MinViewers, f.MaxViewers, f.RequiredTags, f.ExcludedTags, regexpString(f.TitleInclude), regexpString(f.TitleExclude), f.ExcludeMature)
+}
@@ -2,6 +2,7 @@ package model
 
 import (
 	"fmt"
+	"regexp"
 
 	"github.com/Guliveer/twitch-miner-go/internal/jsonutil"
 )
@@ -75,3 +76,59 @@ func (cg *CommunityGoal) String() string {
 		cg.GoalID, cg.Title, cg.IsInStock, cg.PointsContributed, cg.AmountNeeded, cg.Status)
 }
 
+
+// GoalPolicy limits how many points are contributed to community goals.
+// Zero values disable a limit. Titles, when set, is an allowlist of goal
+// title patterns. WithinPercentage only allows contributions once the
+// points still needed are at most that share of the goal amount.
+type GoalPolicy struct {
+	MaxBalancePercentage int `json:"max_balance_percentage"`
+	MaxPerGoal int `json:"max_per_goal"`
+	MaxPerStream int `json:"max_per_stream"`
+	Titles []*regexp.Regexp `json:"-"`
+	Reserve int `json:"reserve"`
+	WithinPercentage int `json:"within_percentage"`
+}
+
+// Contribution returns how many points to contribute to a goal, or 0 and
+// the reason nothing is contributed. userLeft is what Twitch still lets the
+// user contribute this stream, streamContributed the points already given to
+// all goals this stream and goalContributed those already given to this goal.
+// A nil policy only applies Twitch's limits and the balance.
+func (p *GoalPolicy) Contribution(goal *CommunityGoal, balance, userLeft, streamContributed, goalContributed int) (int, string) {
+	amount := min(goal.AmountLeft(), userLeft, balance)
+	if p == nil || amount <= 0 {
+		return max(amount, 0), ""
+	}
+
+	if len(p.Titles) > 0 && !anyRegexpMatches(p.Titles, goal.Title) {
+		return 0, "title not in allowlist"
+	}
+	if p.WithinPercentage > 0 && goal.AmountNeeded > 0 &&
+		goal.AmountLeft()*100 > p.WithinPercentage*goal.AmountNeeded {
+		return 0, fmt.Sprintf("goal not within %d%% of completion", p.WithinPercentage)
+	}
+
+	if p.Reserve > 0 {
+		amount = min(amount, balance-p.Reserve)
+	}
+	if p.MaxBalancePercentage > 0 {
+		amount = min(amount, balance*p.MaxBalancePercentage/100)
+	}
+	if p.MaxPerGoal > 0 {
+		amount = min(amount, p.MaxPerGoal-goalContributed)
+	}
+	if p.MaxPerStream > 0 {
+		amount = min(amount, p.MaxPerStream-streamContributed)
+	}
+	if amount <= 0 {
+		return 0, "limit reached"
+	}
+	return amount, ""
+}
+
+// String returns a human-readable representation of the goal policy.
+func (p *GoalPolicy) String() string {
+	return fmt.Sprintf("GoalPolicy(max_balance_percentage=%d, max_per_goal=%d, max_per_stream=%d, titles=%d, reserve=%d, within_percentage=%d)",
+		p.MaxBalancePercentage, p.MaxPerGoal, p.MaxPerStream, len(p.Titles), p.Reserve, p.WithinPercentage)
+}
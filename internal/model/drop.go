@@ -60,6 +60,16 @@ func (d *Drop) Update(hasPreconditionsMet bool, currentMinutesWatched int, dropI
 	d.PercentageProgress = updatedPercentage
 }
 
+// SetProgress applies progress reported by a drop-progress PubSub message.
+// minutesRequired is ignored when zero.
+func (d *Drop) SetProgress(currentMinutesWatched, minutesRequired int) {
+	if minutesRequired > 0 {
+		d.MinutesRequired = minutesRequired
+	}
+	hasPreconditionsMet := d.HasPreconditionsMet == nil || *d.HasPreconditionsMet
+	d.Update(hasPreconditionsMet, currentMinutesWatched, d.DropInstanceID, d.IsClaimed)
+}
+
 // ProgressBar returns a text-based progress bar for the drop.
 func (d *Drop) ProgressBar() string {
 	progress := d.PercentageProgress / 2
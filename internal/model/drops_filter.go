@@ -0,0 +1,103 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DropsFilter restricts which drop campaigns the miner works on. Games are
+// matched case-insensitively against the campaign game's name, display name
+// or slug. Benefit patterns apply to individual drops; a campaign with no
+// drops left after benefit filtering is filtered out.
+type DropsFilter struct {
+	IncludeGames []string `json:"include_games,omitempty"`
+	ExcludeGames []string `json:"exclude_games,omitempty"`
+	IncludeBenefits []*regexp.Regexp `json:"-"`
+	ExcludeBenefits []*regexp.Regexp `json:"-"`
+	SkipAccountLinking bool `json:"skip_account_linking"`
+}
+
+// IsEmpty reports whether the filter lets every campaign through.
+func (f *DropsFilter) IsEmpty() bool {
+	return len(f.IncludeGames) == 0 && len(f.ExcludeGames) == 0 &&
+		len(f.IncludeBenefits) == 0 && len(f.ExcludeBenefits) == 0 &&
+		!f.SkipAccountLinking
+}
+
+// Apply removes the campaign's drops whose benefits are filtered out and
+// returns why the campaign as a whole is filtered, or "" if it is kept.
+func (f *DropsFilter) Apply(campaign *Campaign) string {
+	if len(f.IncludeGames) > 0 && !campaignGameIn(campaign, f.IncludeGames) {
+		return "game not in include_games"
+	}
+	if len(f.ExcludeGames) > 0 && campaignGameIn(campaign, f.ExcludeGames) {
+		return "game in exclude_games"
+	}
+
+	if f.SkipAccountLinking {
+		for _, drop := range campaign.Drops {
+			if drop.HasPreconditionsMet != nil && !*drop.HasPreconditionsMet {
+				return "requires account linking"
+			}
+		}
+	}
+
+	if len(f.IncludeBenefits) == 0 && len(f.ExcludeBenefits) == 0 {
+		return ""
+	}
+
+	kept := make([]*Drop, 0, len(campaign.Drops))
+	for _, drop := range campaign.Drops {
+		if f.benefitAllowed(drop.Benefit) {
+			kept = append(kept, drop)
+		}
+	}
+	campaign.Drops = kept
+	if len(kept) == 0 {
+		return "no drops match the benefit filters"
+	}
+	return ""
+}
+
+func (f *DropsFilter) benefitAllowed(benefit string) bool {
+	if len(f.IncludeBenefits) > 0 && !anyRegexpMatches(f.IncludeBenefits, benefit) {
+		return false
+	}
+	return !anyRegexpMatches(f.ExcludeBenefits, benefit)
+}
+
+// String returns a human-readable representation of the drops filter.
+func (f *DropsFilter) String() string {
+	return fmt.Sprintf("DropsFilter(include_games=%v, exclude_games=%v, include_benefits=%d, exclude_benefits=%d, skip_account_linking=%t)",
+		f.IncludeGames, f.ExcludeGames, len(f.IncludeBenefits), len(f.ExcludeBenefits), f.SkipAccountLinking)
+}
+
+func campaignGameIn(campaign *Campaign, games []string) bool {
+	return gameIn(campaign.Game, games)
+}
+
+// gameIn reports whether games names the game by name, display name or
+// slug, ignoring case.
+func gameIn(info *GameInfo, games []string) bool {
+	if info == nil {
+		return false
+	}
+	for _, game := range games {
+		if strings.EqualFold(game, info.Name) ||
+			strings.EqualFold(game, info.DisplayName) ||
+			strings.EqualFold(game, info.Slug) {
+			return true
+		}
+	}
+	return false
+}
+
+func anyRegexpMatches(patterns []*regexp.Regexp, s string) bool {
+	for _, re := range patterns {
+		if re.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
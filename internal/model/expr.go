@@ -0,0 +1,406 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Expression limits keep user-defined strategies cheap to evaluate.
+const (
+	maxExprLength = 1024
+	maxExprDepth  = 64
+)
+
+// ExprVariables lists the variables a strategy expression may reference.
+// Outcome fields refer to the outcome being scored (or the chosen outcome
+// for an amount expression).
+var ExprVariables = []string{
+	"odds", "odds_percentage", "percentage_users",
+	"top_points", "total_points", "total_users",
+	"balance", "seconds_left", "outcome_index", "outcomes",
+}
+
+// exprFuncs maps the built-in functions to their arity.
+var exprFuncs = map[string]int{
+	"min": 2, "max": 2, "abs": 1, "floor": 1, "ceil": 1, "round": 1,
+	"sqrt": 1, "log": 1, "clamp": 3, "if": 3,
+}
+
+// Expr is a compiled arithmetic expression over named float64 variables.
+// It supports numbers, the variables in ExprVariables, the operators
+// + - * / % < <= > >= == != && || !, parentheses and the functions
+// min, max, abs, floor, ceil, round, sqrt, log, clamp and if. Comparisons
+// and logical operators yield 1 or 0. Expressions cannot loop, call out or
+// allocate, so evaluating untrusted input is safe.
+type Expr struct {
+	src  string
+	root exprNode
+}
+
+// CompileExpr parses and validates an expression.
+func CompileExpr(src string) (*Expr, error) {
+	if strings.TrimSpace(src) == "" {
+		return nil, fmt.Errorf("empty expression")
+	}
+	if len(src) > maxExprLength {
+		return nil, fmt.Errorf("expression longer than %d characters", maxExprLength)
+	}
+
+	tokens, err := tokenizeExpr(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &exprParser{tokens: tokens}
+	root, err := p.parseBinary(0, 0)
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, fmt.Errorf("unexpected %q at position %d", tok.text, tok.pos)
+	}
+
+	return &Expr{src: src, root: root}, nil
+}
+
+// String returns the expression source.
+func (e *Expr) String() string {
+	return e.src
+}
+
+// Eval evaluates the expression. Unknown variables evaluate to 0. Division
+// or modulo by zero yields NaN, which callers treat as "no value".
+func (e *Expr) Eval(vars map[string]float64) float64 {
+	return e.root.eval(vars)
+}
+
+type exprNode interface {
+	eval(vars map[string]float64) float64
+}
+
+type numberNode float64
+
+func (n numberNode) eval(map[string]float64) float64 { return float64(n) }
+
+type varNode string
+
+func (n varNode) eval(vars map[string]float64) float64 { return vars[string(n)] }
+
+type unaryNode struct {
+	op      string
+	operand exprNode
+}
+
+func (n *unaryNode) eval(vars map[string]float64) float64 {
+	v := n.operand.eval(vars)
+	if n.op == "!" {
+		return boolFloat(v == 0)
+	}
+	return -v
+}
+
+type binaryNode struct {
+	op          string
+	left, right exprNode
+}
+
+func (n *binaryNode) eval(vars map[string]float64) float64 {
+	l := n.left.eval(vars)
+	switch n.op {
+	case "&&":
+		if l == 0 {
+			return 0
+		}
+		return boolFloat(n.right.eval(vars) != 0)
+	case "||":
+		if l != 0 {
+			return 1
+		}
+		return boolFloat(n.right.eval(vars) != 0)
+	}
+
+	r := n.right.eval(vars)
+	switch n.op {
+	case "+":
+		return l + r
+	case "-":
+		return l - r
+	case "*":
+		return l * r
+	case "/":
+		if r == 0 {
+			return math.NaN()
+		}
+		return l / r
+	case "%":
+		if r == 0 {
+			return math.NaN()
+		}
+		return math.Mod(l, r)
+	case "<":
+		return boolFloat(l < r)
+	case "<=":
+		return boolFloat(l <= r)
+	case ">":
+		return boolFloat(l > r)
+	case ">=":
+		return boolFloat(l >= r)
+	case "==":
+		return boolFloat(l == r)
+	case "!=":
+		return boolFloat(l != r)
+	default:
+		return math.NaN()
+	}
+}
+
+type callNode struct {
+	name string
+	args []exprNode
+}
+
+func (n *callNode) eval(vars map[string]float64) float64 {
+	if n.name == "if" {
+		if n.args[0].eval(vars) != 0 {
+			return n.args[1].eval(vars)
+		}
+		return n.args[2].eval(vars)
+	}
+
+	a := n.args[0].eval(vars)
+	switch n.name {
+	case "min":
+		return math.Min(a, n.args[1].eval(vars))
+	case "max":
+		return math.Max(a, n.args[1].eval(vars))
+	case "abs":
+		return math.Abs(a)
+	case "floor":
+		return math.Floor(a)
+	case "ceil":
+		return math.Ceil(a)
+	case "round":
+		return math.Round(a)
+	case "sqrt":
+		return math.Sqrt(a)
+	case "log":
+		return math.Log(a)
+	case "clamp":
+		return math.Max(n.args[1].eval(vars), math.Min(a, n.args[2].eval(vars)))
+	default:
+		return math.NaN()
+	}
+}
+
+func boolFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokNumber
+	tokIdent
+	tokOp
+	tokLParen
+	tokRParen
+	tokComma
+)
+
+type exprToken struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// exprOperators is ordered so two-character operators match first.
+var exprOperators = []string{"<=", ">=", "==", "!=", "&&", "||", "+", "-", "*", "/", "%", "<", ">", "!"}
+
+func tokenizeExpr(src string) ([]exprToken, error) {
+	var tokens []exprToken
+	for i := 0; i < len(src); {
+		c := rune(src[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case unicode.IsDigit(c) || c == '.':
+			start := i
+			for i < len(src) && (unicode.IsDigit(rune(src[i])) || src[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, exprToken{kind: tokNumber, text: src[start:i], pos: start})
+		case unicode.IsLetter(c) || c == '_':
+			start := i
+			for i < len(src) && (unicode.IsLetter(rune(src[i])) || unicode.IsDigit(rune(src[i])) || src[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, exprToken{kind: tokIdent, text: src[start:i], pos: start})
+		case c == '(':
+			tokens = append(tokens, exprToken{kind: tokLParen, text: "(", pos: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, exprToken{kind: tokRParen, text: ")", pos: i})
+			i++
+		case c == ',':
+			tokens = append(tokens, exprToken{kind: tokComma, text: ",", pos: i})
+			i++
+		default:
+			matched := false
+			for _, op := range exprOperators {
+				if strings.HasPrefix(src[i:], op) {
+					tokens = append(tokens, exprToken{kind: tokOp, text: op, pos: i})
+					i += len(op)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, fmt.Errorf("unexpected character %q at position %d", c, i)
+			}
+		}
+	}
+	return append(tokens, exprToken{kind: tokEOF, text: "end of expression", pos: len(src)}), nil
+}
+
+// exprPrecedence gives the binding power of binary operators.
+var exprPrecedence = map[string]int{
+	"||": 1,
+	"&&": 2,
+	"==": 3, "!=": 3,
+	"<": 4, "<=": 4, ">": 4, ">=": 4,
+	"+": 5, "-": 5,
+	"*": 6, "/": 6, "%": 6,
+}
+
+type exprParser struct {
+	tokens []exprToken
+	pos    int
+}
+
+func (p *exprParser) peek() exprToken {
+	return p.tokens[p.pos]
+}
+
+func (p *exprParser) next() exprToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// parseBinary parses operators binding tighter than minPrec (precedence climbing).
+func (p *exprParser) parseBinary(minPrec, depth int) (exprNode, error) {
+	if depth > maxExprDepth {
+		return nil, fmt.Errorf("expression nested too deeply")
+	}
+
+	left, err := p.parseUnary(depth + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		tok := p.peek()
+		prec, ok := exprPrecedence[tok.text]
+		if tok.kind != tokOp || !ok || prec <= minPrec {
+			return left, nil
+		}
+		p.next()
+		right, err := p.parseBinary(prec, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: tok.text, left: left, right: right}
+	}
+}
+
+func (p *exprParser) parseUnary(depth int) (exprNode, error) {
+	if depth > maxExprDepth {
+		return nil, fmt.Errorf("expression nested too deeply")
+	}
+
+	tok := p.peek()
+	if tok.kind == tokOp && (tok.text == "-" || tok.text == "!") {
+		p.next()
+		operand, err := p.parseUnary(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return &unaryNode{op: tok.text, operand: operand}, nil
+	}
+	return p.parsePrimary(depth)
+}
+
+func (p *exprParser) parsePrimary(depth int) (exprNode, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokNumber:
+		v, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at position %d", tok.text, tok.pos)
+		}
+		return numberNode(v), nil
+
+	case tokIdent:
+		if p.peek().kind == tokLParen {
+			return p.parseCall(tok, depth)
+		}
+		for _, name := range ExprVariables {
+			if name == tok.text {
+				return varNode(tok.text), nil
+			}
+		}
+		return nil, fmt.Errorf("unknown variable %q at position %d", tok.text, tok.pos)
+
+	case tokLParen:
+		inner, err := p.parseBinary(0, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokRParen {
+			return nil, fmt.Errorf("expected ')' at position %d", closing.pos)
+		}
+		return inner, nil
+
+	default:
+		return nil, fmt.Errorf("unexpected %q at position %d", tok.text, tok.pos)
+	}
+}
+
+func (p *exprParser) parseCall(name exprToken, depth int) (exprNode, error) {
+	arity, ok := exprFuncs[name.text]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %q at position %d", name.text, name.pos)
+	}
+	p.next() // consume '('
+
+	var args []exprNode
+	if p.peek().kind != tokRParen {
+		for {
+			arg, err := p.parseBinary(0, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			if p.peek().kind != tokComma {
+				break
+			}
+			p.next()
+		}
+	}
+	if closing := p.next(); closing.kind != tokRParen {
+		return nil, fmt.Errorf("expected ')' at position %d", closing.pos)
+	}
+	if len(args) != arity {
+		return nil, fmt.Errorf("function %s takes %d arguments, got %d", name.text, arity, len(args))
+	}
+	return &callNode{name: name.text, args: args}, nil
+}
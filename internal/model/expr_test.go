@@ -0,0 +1,202 @@
+package model
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestExprEval(t *testing.T) {
+	vars := map[string]float64{"odds": 2.5, "percentage_users": 40, "balance": 0}
+
+	tests := []struct {
+		src  string
+		want float64
+	}{
+		// Precedence and associativity.
+		{"1 + 2 * 3", 7},
+		{"(1 + 2) * 3", 9},
+		{"10 - 4 - 3", 3},
+		{"100 / 10 / 5", 2},
+		{"2 * 7 % 4", 2},
+		{"1 + 2 < 4", 1},
+		{"1 < 2 == 1", 1},
+		{"0 || 1 && 0", 0},
+		{"1 || 0 && 0", 1},
+		{"2 <= 2 && 3 >= 4", 0},
+		{"1 != 2", 1},
+
+		// Unary operators.
+		{"-2 * 3", -6},
+		{"--2", 2},
+		{"2 - -1", 3},
+		{"-odds", -2.5},
+		{"!0", 1},
+		{"!5", 0},
+		{"!!3", 1},
+		{"!(1 < 2)", 0},
+
+		// Built-in functions.
+		{"min(1, 2)", 1},
+		{"max(1, 2)", 2},
+		{"abs(-3)", 3},
+		{"floor(1.7)", 1},
+		{"ceil(1.2)", 2},
+		{"round(2.5)", 3},
+		{"sqrt(9)", 3},
+		{"log(1)", 0},
+		{"clamp(5, 0, 3)", 3},
+		{"clamp(-1, 0, 3)", 0},
+		{"if(1, 2, 3)", 2},
+		{"if(0, 2, 3)", 3},
+
+		// Variables; a known variable missing from vars is 0.
+		{"odds * percentage_users", 100},
+		{"total_users + 1", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			expr, err := CompileExpr(tt.src)
+			if err != nil {
+				t.Fatalf("CompileExpr: %v", err)
+			}
+			if got := expr.Eval(vars); got != tt.want {
+				t.Errorf("Eval = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExprDivisionByZero(t *testing.T) {
+	for _, src := range []string{"1 / 0", "5 % 0", "odds / balance"} {
+		expr, err := CompileExpr(src)
+		if err != nil {
+			t.Fatalf("CompileExpr(%q): %v", src, err)
+		}
+		if got := expr.Eval(map[string]float64{"odds": 2}); !math.IsNaN(got) {
+			t.Errorf("Eval(%q) = %v, want NaN", src, got)
+		}
+	}
+}
+
+func TestCompileExprErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"empty", "  ", "empty expression"},
+		{"unknown variable", "odds + foo", `unknown variable "foo"`},
+		{"unknown function", "pow(2, 3)", `unknown function "pow"`},
+		{"dangling operator", "1 +", "unexpected"},
+		{"unclosed parenthesis", "(1 + 2", "expected ')'"},
+		{"missing operator", "1 2", "unexpected"},
+		{"bad character", "odds # 2", "unexpected character"},
+		{"bad number", "1.2.3", "invalid number"},
+		{"too long", "1" + strings.Repeat("+1", 512), "longer than 1024"},
+		{"nested parentheses", strings.Repeat("(", 64) + "1" + strings.Repeat(")", 64), "nested too deeply"},
+		{"chained unary", strings.Repeat("-", 65) + "1", "nested too deeply"},
+	}
+
+	// Every built-in function with one argument too few and one too many.
+	for name, arity := range exprFuncs {
+		for _, n := range []int{arity - 1, arity + 1} {
+			args := strings.TrimSuffix(strings.Repeat("1, ", n), ", ")
+			tests = append(tests, struct {
+				name string
+				src  string
+				want string
+			}{name + " arity", name + "(" + args + ")", "arguments"})
+		}
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CompileExpr(tt.src)
+			if err == nil {
+				t.Fatalf("CompileExpr(%q) succeeded, want error containing %q", tt.src, tt.want)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %q, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompileExprLimits(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+	}{
+		{"1024 characters", "1" + strings.Repeat("+1", 511) + " "},
+		{"nested parentheses", strings.Repeat("(", 20) + "1" + strings.Repeat(")", 20)},
+		{"chained unary", strings.Repeat("-", 30) + "1"},
+		{"long sum", strings.TrimSuffix(strings.Repeat("odds + ", 100), " + ")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := CompileExpr(tt.src); err != nil {
+				t.Errorf("CompileExpr: %v", err)
+			}
+		})
+	}
+}
+
+// customBet returns a bet that uses the CUSTOM strategy with the given
+// expressions.
+func customBet(t *testing.T, choice, amount string) *Bet {
+	t.Helper()
+
+	custom := &CustomStrategy{}
+	var err error
+	if custom.Choice, err = CompileExpr(choice); err != nil {
+		t.Fatalf("CompileExpr(%q): %v", choice, err)
+	}
+	if amount != "" {
+		if custom.Amount, err = CompileExpr(amount); err != nil {
+			t.Fatalf("CompileExpr(%q): %v", amount, err)
+		}
+	}
+
+	settings := DefaultBetSettings()
+	settings.Strategy = StrategyCustom
+	settings.Custom = custom
+	settings.MaxPoints = 1_000_000
+	return NewBet([]Outcome{
+		{ID: "a", Odds: 2, PercentageUsers: 30},
+		{ID: "b", Odds: 1.5, PercentageUsers: 70},
+	}, settings)
+}
+
+func TestCustomStrategyCalculate(t *testing.T) {
+	tests := []struct {
+		name       string
+		choice     string
+		amount     string
+		balance    int
+		wantChoice int
+		wantAmount int
+	}{
+		{"choice and amount", "odds * percentage_users", "min(balance * 0.1, 500)", 1000, 1, 100},
+		{"amount capped by expression", "odds * percentage_users", "min(balance * 0.1, 500)", 10000, 1, 500},
+		{"amount capped by balance", "odds", "balance * 2", 1000, 0, 1000},
+		{"NaN amount bets nothing", "odds", "balance / 0", 1000, 0, 0},
+		{"negative amount bets nothing", "odds", "-1", 1000, 0, 0},
+		{"no amount uses percentage sizing", "outcome_index == 1", "", 1000, 1, 50},
+		{"NaN choice skips the outcome", "if(outcome_index == 0, 1 / 0, 1)", "", 1000, 1, 50},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision := customBet(t, tt.choice, tt.amount).Calculate(tt.balance)
+			if decision.Choice != tt.wantChoice {
+				t.Errorf("Choice = %d, want %d", decision.Choice, tt.wantChoice)
+			}
+			if decision.Amount != tt.wantAmount {
+				t.Errorf("Amount = %d, want %d", decision.Amount, tt.wantAmount)
+			}
+		})
+	}
+}
@@ -39,6 +39,17 @@ const (
 	// Community goal messages
 	MsgTypeGoalContribution MessageType = "community-goal-contribution"
 	MsgTypeGoalUpdated      MessageType = "community-goal-updated"
+
+	// Drop messages
+	MsgTypeDropProgress MessageType = "drop-progress"
+	MsgTypeDropClaim    MessageType = "drop-claim"
+
+	// Hype train messages
+	MsgTypeHypeTrainStart   MessageType = "hype-train-start"
+	MsgTypeHypeTrainLevelUp MessageType = "hype-train-level-up"
+
+	// Poll messages
+	MsgTypePollCreate MessageType = "POLL_CREATE"
 )
 
 // Message represents a parsed PubSub message.
@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"math"
 	"math/rand/v2"
+	"sort"
 	"sync"
 	"time"
 
+	"github.com/Guliveer/twitch-miner-go/internal/constants"
 	"github.com/Guliveer/twitch-miner-go/internal/utils"
 )
 
@@ -23,6 +25,7 @@ const (
 	// StrategySmartMoney bets on the outcome with the highest top predictor points.
 	StrategySmartMoney
 	// StrategySmart uses a hybrid approach: high odds if close, most voted otherwise.
+	// Only the first two outcomes are compared; see Bet.smartChoice.
 	StrategySmart
 	// StrategyNumber1 always bets on outcome index 0.
 	StrategyNumber1
@@ -40,6 +43,12 @@ const (
 	StrategyNumber7
 	// StrategyNumber8 always bets on outcome index 7.
 	StrategyNumber8
+	// StrategySmartMulti weighs the voter gap, expected value and top
+	// predictors across all outcomes; see Bet.smartMultiChoice.
+	StrategySmartMulti
+	// StrategyCustom scores outcomes with the user-defined expressions in
+	// BetSettings.Custom.
+	StrategyCustom
 )
 
 // String returns the string representation of a Strategy.
@@ -47,7 +56,7 @@ func (s Strategy) String() string {
 	names := [...]string{
 		"MOST_VOTED", "HIGH_ODDS", "PERCENTAGE", "SMART_MONEY", "SMART",
 		"NUMBER_1", "NUMBER_2", "NUMBER_3", "NUMBER_4",
-		"NUMBER_5", "NUMBER_6", "NUMBER_7", "NUMBER_8",
+		"NUMBER_5", "NUMBER_6", "NUMBER_7", "NUMBER_8", "SMART_MULTI", "CUSTOM",
 	}
 	if int(s) < len(names) {
 		return names[s]
@@ -55,6 +64,16 @@ func (s Strategy) String() string {
 	return "SMART"
 }
 
+// IsValidStrategy reports whether s names a known strategy.
+func IsValidStrategy(s string) bool {
+	for strategy := StrategyMostVoted; strategy <= StrategyCustom; strategy++ {
+		if strategy.String() == s {
+			return true
+		}
+	}
+	return false
+}
+
 // ParseStrategy converts a string to a Strategy value.
 func ParseStrategy(s string) Strategy {
 	switch s {
@@ -84,6 +103,10 @@ func ParseStrategy(s string) Strategy {
 		return StrategyNumber7
 	case "NUMBER_8":
 		return StrategyNumber8
+	case "SMART_MULTI":
+		return StrategySmartMulti
+	case "CUSTOM":
+		return StrategyCustom
 	default:
 		return StrategySmart
 	}
@@ -101,6 +124,8 @@ const (
 	ConditionGTE
 	// ConditionLTE is the less-than-or-equal operator.
 	ConditionLTE
+	// ConditionBetween is the inclusive range operator (Value <= x <= Max).
+	ConditionBetween
 )
 
 // String returns the string representation of a Condition.
@@ -114,6 +139,8 @@ func (c Condition) String() string {
 		return "GTE"
 	case ConditionLTE:
 		return "LTE"
+	case ConditionBetween:
+		return "BETWEEN"
 	default:
 		return "GT"
 	}
@@ -130,11 +157,47 @@ func ParseCondition(s string) Condition {
 		return ConditionGTE
 	case "LTE":
 		return ConditionLTE
+	case "BETWEEN":
+		return ConditionBetween
 	default:
 		return ConditionGT
 	}
 }
 
+// FilterMatch defines how multiple filter conditions are combined.
+type FilterMatch int
+
+const (
+	// FilterMatchAll places the bet only if every condition passes.
+	FilterMatchAll FilterMatch = iota
+	// FilterMatchAny places the bet if at least one condition passes.
+	FilterMatchAny
+)
+
+// String returns the string representation of a FilterMatch.
+func (fm FilterMatch) String() string {
+	switch fm {
+	case FilterMatchAll:
+		return "ALL"
+	case FilterMatchAny:
+		return "ANY"
+	default:
+		return "ALL"
+	}
+}
+
+// ParseFilterMatch converts a string to a FilterMatch value.
+func ParseFilterMatch(s string) FilterMatch {
+	switch s {
+	case "ALL":
+		return FilterMatchAll
+	case "ANY":
+		return FilterMatchAny
+	default:
+		return FilterMatchAll
+	}
+}
+
 // OutcomeKey defines the keys used to access outcome statistics.
 type OutcomeKey string
 
@@ -157,6 +220,18 @@ const (
 	OutcomeKeyDecisionPoints OutcomeKey = "decision_points"
 )
 
+// Valid reports whether k is one of the known outcome keys.
+func (k OutcomeKey) Valid() bool {
+	switch k {
+	case OutcomeKeyPercentageUsers, OutcomeKeyOddsPercentage, OutcomeKeyOdds,
+		OutcomeKeyTopPoints, OutcomeKeyTotalUsers, OutcomeKeyTotalPoints,
+		OutcomeKeyDecisionUsers, OutcomeKeyDecisionPoints:
+		return true
+	default:
+		return false
+	}
+}
+
 // DelayMode defines how the prediction delay is calculated.
 type DelayMode int
 
@@ -197,18 +272,84 @@ func ParseDelayMode(s string) DelayMode {
 	}
 }
 
+// CustomStrategy holds the user-defined expressions of StrategyCustom.
+// Choice scores each outcome and the highest score wins; outcomes scoring
+// NaN are ignored. Amount, if set, gives the stake for the chosen outcome
+// instead of the sizing mode.
+type CustomStrategy struct {
+	Choice *Expr `json:"-"`
+	Amount *Expr `json:"-"`
+}
+
+// SizingMode defines how the bet amount is calculated once an outcome is chosen.
+type SizingMode int
+
+const (
+	// SizingPercentage bets a flat percentage of the balance.
+	SizingPercentage SizingMode = iota
+	// SizingKelly bets a fraction of the Kelly-optimal stake for the chosen outcome.
+	SizingKelly
+)
+
+// String returns the string representation of a SizingMode.
+func (m SizingMode) String() string {
+	switch m {
+	case SizingPercentage:
+		return "PERCENTAGE"
+	case SizingKelly:
+		return "KELLY"
+	default:
+		return "PERCENTAGE"
+	}
+}
+
+// ParseSizingMode converts a string to a SizingMode value.
+func ParseSizingMode(s string) SizingMode {
+	switch s {
+	case "PERCENTAGE":
+		return SizingPercentage
+	case "KELLY":
+		return SizingKelly
+	default:
+		return SizingPercentage
+	}
+}
+
 // FilterCondition defines a condition for filtering predictions before betting.
+// Max is only used by ConditionBetween as the inclusive upper bound.
 type FilterCondition struct {
 	By OutcomeKey `json:"by" yaml:"by"`
 	Where Condition `json:"where" yaml:"where"`
 	Value float64 `json:"value" yaml:"value"`
+	Max float64 `json:"max,omitempty" yaml:"max"`
 }
 
 // String returns a human-readable representation of the filter condition.
 func (fc *FilterCondition) String() string {
+	if fc.Where == ConditionBetween {
+		return fmt.Sprintf("FilterCondition(by=%s, where=%s, value=%.2f, max=%.2f)", fc.By, fc.Where, fc.Value, fc.Max)
+	}
 	return fmt.Sprintf("FilterCondition(by=%s, where=%s, value=%.2f)", fc.By, fc.Where, fc.Value)
 }
 
+// Passes reports whether value satisfies the condition.
+func (fc *FilterCondition) Passes(value float64) bool {
+	switch fc.Where {
+	case ConditionGT:
+		return value > fc.Value
+	case ConditionLT:
+		return value < fc.Value
+	case ConditionGTE:
+		return value >= fc.Value
+	case ConditionLTE:
+		return value <= fc.Value
+	case ConditionBetween:
+		return value >= fc.Value && value <= fc.Max
+	default:
+		return false
+	}
+}
+
 // BetSettings holds configuration for automatic prediction betting.
 type BetSettings struct {
 	Strategy Strategy `json:"strategy" yaml:"strategy"`
@@ -218,8 +359,15 @@ type BetSettings struct {
 	MinimumPoints int `json:"minimum_points" yaml:"minimum_points"`
 	StealthMode bool `json:"stealth_mode" yaml:"stealth_mode"`
 	FilterCondition *FilterCondition `json:"filter_condition,omitempty" yaml:"filter_condition"`
+	FilterConditions []FilterCondition `json:"filter_conditions,omitempty" yaml:"filter_conditions"`
+	FilterMatch FilterMatch `json:"filter_match" yaml:"filter_match"`
+	TitleRules []TitleRule `json:"title_rules,omitempty" yaml:"title_rules"`
+	Bankroll BankrollSettings `json:"bankroll" yaml:"bankroll"`
+	Custom *CustomStrategy `json:"-" yaml:"-"`
 	Delay float64 `json:"delay" yaml:"delay"`
 	DelayMode DelayMode `json:"delay_mode" yaml:"delay_mode"`
+	Sizing SizingMode `json:"sizing" yaml:"sizing"`
+	KellyFraction float64 `json:"kelly_fraction" yaml:"kelly_fraction"`
 }
 
 // DefaultBetSettings returns BetSettings with default values.
@@ -233,13 +381,25 @@ func DefaultBetSettings() *BetSettings {
 		StealthMode:   false,
 		Delay:         6,
 		DelayMode:     DelayModeFromEnd,
+		Sizing:        SizingPercentage,
+		KellyFraction: 0.5,
 	}
 }
 
 // String returns a human-readable representation of the bet settings.
 func (bs *BetSettings) String() string {
-	return fmt.Sprintf("BetSettings(strategy=%s, percentage=%d, percentage_gap=%d, max_points=%d, minimum_points=%d, stealth_mode=%t)",
-		bs.Strategy, bs.Percentage, bs.PercentageGap, bs.MaxPoints, bs.MinimumPoints, bs.StealthMode)
+	return fmt.Sprintf("BetSettings(strategy=%s, sizing=%s, percentage=%d, percentage_gap=%d, max_points=%d, minimum_points=%d, stealth_mode=%t)",
+		bs.Strategy, bs.Sizing, bs.Percentage, bs.PercentageGap, bs.MaxPoints, bs.MinimumPoints, bs.StealthMode)
+}
+
+// Filters returns every configured filter condition: the legacy single
+// FilterCondition first, followed by FilterConditions.
+func (bs *BetSettings) Filters() []FilterCondition {
+	filters := make([]FilterCondition, 0, len(bs.FilterConditions)+1)
+	if bs.FilterCondition != nil {
+		filters = append(filters, *bs.FilterCondition)
+	}
+	return append(filters, bs.FilterConditions...)
 }
 
 // Outcome represents a single prediction outcome with computed statistics.
@@ -262,13 +422,34 @@ type BetDecision struct {
 	OutcomeID string `json:"id"`
 }
 
+// BelowMinimum reports whether the stake is too small to be placed.
+func (d BetDecision) BelowMinimum() bool {
+	return d.Amount < constants.MinBetAmount
+}
+
 // Bet holds the state of a prediction bet calculation.
 type Bet struct {
 	Outcomes []Outcome `json:"outcomes"`
 	Decision BetDecision `json:"decision"`
 	TotalUsers int `json:"total_users"`
 	TotalPoints int `json:"total_points"`
+	Balance int `json:"balance"`
+	SecondsLeft float64 `json:"seconds_left"`
 	Settings *BetSettings `json:"-"`
+
+	// ForcedOutcomeID, when set, overrides the strategy's choice with the
+	// outcome of that ID (e.g. forced by a title rule).
+	ForcedOutcomeID string `json:"forced_outcome_id,omitempty"`
+
+	// WinRates holds the historical win frequency of each outcome index for
+	// this streamer. When set, KELLY sizing uses it instead of PercentageUsers
+	// to estimate the win probability.
+	WinRates []float64 `json:"-"`
+
+	// AccountBalanceFloor is the account-wide bankroll balance floor. The
+	// stake never takes the balance below it or below the streamer's own
+	// floor, whichever is higher.
+	AccountBalanceFloor int `json:"-"`
 }
 
 // NewBet creates a new Bet from a list of outcomes and settings.
@@ -351,6 +532,112 @@ func (b *Bet) returnChoice(key OutcomeKey) int {
 	return largest
 }
 
+// rankedBy returns outcome indices ordered by key, highest first. Ties keep
+// the original outcome order.
+func (b *Bet) rankedBy(key OutcomeKey) []int {
+	indices := make([]int, len(b.Outcomes))
+	for i := range indices {
+		indices[i] = i
+	}
+	sort.SliceStable(indices, func(i, j int) bool {
+		return b.outcomeValue(indices[i], key) > b.outcomeValue(indices[j], key)
+	})
+	return indices
+}
+
+// smartChoice compares the voter share of the first two outcomes. If they
+// are within PercentageGap it bets on the highest odds, otherwise on the most
+// voted. With more than two outcomes the gap is still taken between outcomes
+// 0 and 1, but the choice is made across all outcomes; SMART_MULTI ranks
+// every outcome instead.
+func (b *Bet) smartChoice() int {
+	difference := math.Abs(b.Outcomes[0].PercentageUsers - b.Outcomes[1].PercentageUsers)
+	if difference < float64(b.Settings.PercentageGap) {
+		return b.returnChoice(OutcomeKeyOdds)
+	}
+	return b.returnChoice(OutcomeKeyTotalUsers)
+}
+
+// smartMultiChoice picks an outcome of a prediction with any number of
+// outcomes:
+//  1. If the most voted outcome leads the runner-up by at least
+//     PercentageGap points of voter share, the crowd is trusted and the most
+//     voted outcome is chosen.
+//  2. Otherwise, if one outcome's top predictor has staked at least twice
+//     as much as the top predictor of every other outcome, that outcome is
+//     chosen.
+//  3. Otherwise the outcome with the highest expected value is chosen, where
+//     EV = voter share × odds - 1 rewards outcomes the crowd favours more than
+//     the money does. Outcomes nobody voted for are ignored, and ties go to
+//     the more voted outcome.
+func (b *Bet) smartMultiChoice() int {
+	ranked := b.rankedBy(OutcomeKeyPercentageUsers)
+	if len(ranked) == 1 {
+		return ranked[0]
+	}
+
+	first, second := ranked[0], ranked[1]
+	if b.Outcomes[first].PercentageUsers-b.Outcomes[second].PercentageUsers >= float64(b.Settings.PercentageGap) {
+		return first
+	}
+
+	byTopPoints := b.rankedBy(OutcomeKeyTopPoints)
+	topPoints := b.Outcomes[byTopPoints[0]].TopPoints
+	runnerUpPoints := b.Outcomes[byTopPoints[1]].TopPoints
+	if topPoints > 0 && topPoints >= 2*runnerUpPoints {
+		return byTopPoints[0]
+	}
+
+	best, bestEV := first, math.Inf(-1)
+	for _, i := range ranked {
+		outcome := b.Outcomes[i]
+		if outcome.PercentageUsers <= 0 || outcome.Odds <= 0 {
+			continue
+		}
+		if ev := outcome.PercentageUsers/100.0*outcome.Odds - 1; ev > bestEV {
+			best, bestEV = i, ev
+		}
+	}
+	return best
+}
+
+// exprVars returns the expression variables for the outcome at index.
+func (b *Bet) exprVars(index, balance int) map[string]float64 {
+	outcome := b.Outcomes[index]
+	return map[string]float64{
+		"odds":             outcome.Odds,
+		"odds_percentage":  outcome.OddsPercentage,
+		"percentage_users": outcome.PercentageUsers,
+		"top_points":       float64(outcome.TopPoints),
+		"total_points":     float64(outcome.TotalPoints),
+		"total_users":      float64(outcome.TotalUsers),
+		"balance":          float64(balance),
+		"seconds_left":     b.SecondsLeft,
+		"outcome_index":    float64(index),
+		"outcomes":         float64(len(b.Outcomes)),
+	}
+}
+
+// customChoice returns the outcome with the highest Choice score, or -1 if
+// no custom strategy is set or every score is NaN.
+func (b *Bet) customChoice(balance int) int {
+	if b.Settings.Custom == nil || b.Settings.Custom.Choice == nil {
+		return -1
+	}
+
+	best, bestScore := -1, math.Inf(-1)
+	for i := range b.Outcomes {
+		score := b.Settings.Custom.Choice.Eval(b.exprVars(i, balance))
+		if math.IsNaN(score) {
+			continue
+		}
+		if best == -1 || score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	return best
+}
+
 func (b *Bet) returnNumberChoice(number int) int {
 	if len(b.Outcomes) > number {
 		return number
@@ -358,61 +645,125 @@ func (b *Bet) returnNumberChoice(number int) int {
 	return 0
 }
 
-// Skip checks the filter condition and returns whether the bet should be skipped
-// and the compared value.
+// Skip checks the filter conditions and returns whether the bet should be
+// skipped and the compared value of the rule that decided it.
 func (b *Bet) Skip() (bool, float64) {
-	if b.Settings.FilterCondition == nil {
-		return false, 0
+	skip, _, comparedValue := b.CheckFilters()
+	return skip, comparedValue
+}
+
+// CheckFilters evaluates the filter conditions against the current decision.
+// It returns whether the bet should be skipped, the rule that rejected it and
+// that rule's compared value. With FilterMatchAll the first failing rule is
+// reported, or the last compared value if every rule passed; with
+// FilterMatchAny the first passing rule's value is returned, or the first
+// rule is reported if every rule failed.
+func (b *Bet) CheckFilters() (bool, *FilterCondition, float64) {
+	filters := b.Settings.Filters()
+	if len(filters) == 0 {
+		return false, nil, 0
 	}
 
-	fc := b.Settings.FilterCondition
-	key := fc.By
-	condition := fc.Where
-	value := fc.Value
+	var firstFailed *FilterCondition
+	var firstFailedValue, comparedValue float64
+	for i := range filters {
+		fc := &filters[i]
+		comparedValue = b.filterValue(fc.By)
+		passes := fc.Passes(comparedValue)
 
-	resolvedKey := key
-	if key == OutcomeKeyDecisionUsers || key == OutcomeKeyDecisionPoints {
-		if key == OutcomeKeyDecisionUsers {
-			resolvedKey = OutcomeKeyTotalUsers
-		} else {
-			resolvedKey = OutcomeKeyTotalPoints
+		if passes && b.Settings.FilterMatch == FilterMatchAny {
+			return false, nil, comparedValue
 		}
+		if !passes {
+			if b.Settings.FilterMatch == FilterMatchAll {
+				return true, fc, comparedValue
+			}
+			if firstFailed == nil {
+				firstFailed, firstFailedValue = fc, comparedValue
+			}
+		}
+	}
+
+	if b.Settings.FilterMatch == FilterMatchAny {
+		return true, firstFailed, firstFailedValue
+	}
+	return false, nil, comparedValue
+}
+
+// filterValue resolves the value a filter condition on key compares against.
+// total_users and total_points are summed over all outcomes; decision_users,
+// decision_points and the per-outcome keys are read from the chosen outcome.
+func (b *Bet) filterValue(key OutcomeKey) float64 {
+	resolvedKey := key
+	if key == OutcomeKeyDecisionUsers {
+		resolvedKey = OutcomeKeyTotalUsers
+	} else if key == OutcomeKeyDecisionPoints {
+		resolvedKey = OutcomeKeyTotalPoints
 	}
 
-	var comparedValue float64
 	if key == OutcomeKeyTotalUsers || key == OutcomeKeyTotalPoints {
+		var total float64
 		for i := range b.Outcomes {
-			comparedValue += b.outcomeValue(i, resolvedKey)
+			total += b.outcomeValue(i, resolvedKey)
 		}
-	} else {
-		comparedValue = b.outcomeValue(b.Decision.Choice, resolvedKey)
+		return total
 	}
 
-	switch condition {
-	case ConditionGT:
-		if comparedValue > value {
-			return false, comparedValue
-		}
-	case ConditionLT:
-		if comparedValue < value {
-			return false, comparedValue
-		}
-	case ConditionGTE:
-		if comparedValue >= value {
-			return false, comparedValue
-		}
-	case ConditionLTE:
-		if comparedValue <= value {
-			return false, comparedValue
-		}
+	if b.Decision.Choice < 0 || b.Decision.Choice >= len(b.Outcomes) {
+		return 0
 	}
+	return b.outcomeValue(b.Decision.Choice, resolvedKey)
+}
 
-	return true, comparedValue
+// SetWinRates sets the streamer's historical win rates from samples resolved
+// predictions. With fewer than KellyMinHistorySamples samples the history is
+// ignored and KELLY sizing falls back to the share of voters.
+func (b *Bet) SetWinRates(rates []float64, samples int) {
+	if samples < constants.KellyMinHistorySamples {
+		b.WinRates = nil
+		return
+	}
+	b.WinRates = rates
+}
+
+// winProbability estimates the chance that the outcome at index wins, using
+// historical win rates when available and the share of voters otherwise.
+func (b *Bet) winProbability(index int) float64 {
+	if index < len(b.WinRates) {
+		return b.WinRates[index]
+	}
+	return b.Outcomes[index].PercentageUsers / 100.0
+}
+
+// kellyAmount sizes a bet on the chosen outcome with the Kelly criterion
+// f* = p - (1-p)/b, where b is the net odds and p the estimated win
+// probability. The stake is f* times KellyFraction of the balance above
+// MinimumPoints; a non-positive edge yields zero.
+func (b *Bet) kellyAmount(balance int) int {
+	chosen := b.Outcomes[b.Decision.Choice]
+	netOdds := chosen.Odds - 1
+	if netOdds <= 0 {
+		return 0
+	}
+
+	p := b.winProbability(b.Decision.Choice)
+	fraction := p - (1-p)/netOdds
+	if fraction <= 0 {
+		return 0
+	}
+
+	bankroll := balance - b.Settings.MinimumPoints
+	if bankroll <= 0 {
+		return 0
+	}
+
+	return int(float64(bankroll) * fraction * b.Settings.KellyFraction)
 }
 
 // Calculate determines which outcome to bet on and how much to bet.
 func (b *Bet) Calculate(balance int) BetDecision {
 	b.Decision = BetDecision{Choice: -1, Amount: 0, OutcomeID: ""}
+	b.Balance = balance
 
 	switch b.Settings.Strategy {
 	case StrategyMostVoted:
@@ -441,11 +792,21 @@ func (b *Bet) Calculate(balance int) BetDecision {
 		b.Decision.Choice = b.returnNumberChoice(7)
 	case StrategySmart:
 		if len(b.Outcomes) >= 2 {
-			difference := math.Abs(b.Outcomes[0].PercentageUsers - b.Outcomes[1].PercentageUsers)
-			if difference < float64(b.Settings.PercentageGap) {
-				b.Decision.Choice = b.returnChoice(OutcomeKeyOdds)
-			} else {
-				b.Decision.Choice = b.returnChoice(OutcomeKeyTotalUsers)
+			b.Decision.Choice = b.smartChoice()
+		}
+	case StrategySmartMulti:
+		if len(b.Outcomes) >= 1 {
+			b.Decision.Choice = b.smartMultiChoice()
+		}
+	case StrategyCustom:
+		b.Decision.Choice = b.customChoice(balance)
+	}
+
+	if b.ForcedOutcomeID != "" {
+		for i := range b.Outcomes {
+			if b.Outcomes[i].ID == b.ForcedOutcomeID {
+				b.Decision.Choice = i
+				break
 			}
 		}
 	}
@@ -454,10 +815,26 @@ func (b *Bet) Calculate(balance int) BetDecision {
 		chosen := b.Outcomes[b.Decision.Choice]
 		b.Decision.OutcomeID = chosen.ID
 
-		amount := int(float64(balance) * float64(b.Settings.Percentage) / 100.0)
+		var amount int
+		switch b.Settings.Sizing {
+		case SizingKelly:
+			amount = b.kellyAmount(balance)
+		default:
+			amount = int(float64(balance) * float64(b.Settings.Percentage) / 100.0)
+		}
+		if custom := b.Settings.Custom; b.Settings.Strategy == StrategyCustom && custom != nil && custom.Amount != nil {
+			value := custom.Amount.Eval(b.exprVars(b.Decision.Choice, balance))
+			amount = 0
+			if !math.IsNaN(value) && value > 0 {
+				amount = int(math.Min(value, float64(balance)))
+			}
+		}
 		if amount > b.Settings.MaxPoints {
 			amount = b.Settings.MaxPoints
 		}
+		if floor := max(b.Settings.Bankroll.BalanceFloor, b.AccountBalanceFloor); floor > 0 && balance-amount < floor {
+			amount = max(balance-floor, 0)
+		}
 
 		if b.Settings.StealthMode && amount >= chosen.TopPoints && chosen.TopPoints > 0 {
 			stealthReduction := 1.0 + rand.Float64()*4.0
@@ -497,6 +874,10 @@ type EventPrediction struct {
 	BoxFillable bool `json:"box_fillable"`
 	BetConfirmed bool `json:"bet_confirmed"`
 	BetPlaced bool `json:"bet_placed"`
+	BroadcastID string `json:"broadcast_id,omitempty"` // Broadcast of the session the bet was counted in
+	WinningOutcomeID string `json:"winning_outcome_id,omitempty"`
+	Rule string `json:"rule,omitempty"`
+	LocksAt time.Time `json:"locks_at"`
 	Bet *Bet `json:"bet"`
 }
 
@@ -521,6 +902,16 @@ func NewEventPrediction(
 	}
 }
 
+// ApplyTitleRule records a fired title rule and applies its overrides to the
+// bet. forced is the index of the outcome the rule forces, or -1.
+func (ep *EventPrediction) ApplyTitleRule(rule *TitleRule, forced int) {
+	ep.Rule = rule.Name
+	ep.Bet.Settings = rule.Apply(ep.Bet.Settings)
+	if forced >= 0 && forced < len(ep.Bet.Outcomes) {
+		ep.Bet.ForcedOutcomeID = ep.Bet.Outcomes[forced].ID
+	}
+}
+
 // Elapsed returns the seconds elapsed since the prediction was created.
 func (ep *EventPrediction) Elapsed(timestamp time.Time) float64 {
 	return utils.FloatRound(timestamp.Sub(ep.CreatedAt).Seconds(), 2)
@@ -0,0 +1,73 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// TitleRule matches predictions by title and adjusts how they are bet on.
+// A rule fires when Title matches the prediction title and, if Outcome is
+// set, at least one outcome title matches Outcome. When it fires, the
+// prediction is skipped, or the first matching outcome is forced and the
+// optional strategy and percentage overrides are applied.
+type TitleRule struct {
+	Name string `json:"name"`
+	Title *regexp.Regexp `json:"-"`
+	Outcome *regexp.Regexp `json:"-"`
+	Skip bool `json:"skip"`
+	Strategy *Strategy `json:"strategy,omitempty"`
+	Percentage *int `json:"percentage,omitempty"`
+}
+
+// String returns a human-readable representation of the title rule.
+func (r *TitleRule) String() string {
+	return fmt.Sprintf("TitleRule(name=%s, title=%s, outcome=%s, skip=%t)",
+		r.Name, regexpString(r.Title), regexpString(r.Outcome), r.Skip)
+}
+
+// Match reports whether the rule fires for a prediction, and the index of
+// the outcome it forces (-1 if the rule does not force an outcome).
+func (r *TitleRule) Match(title string, outcomes []Outcome) (bool, int) {
+	if r.Title != nil && !r.Title.MatchString(title) {
+		return false, -1
+	}
+	if r.Outcome == nil {
+		return true, -1
+	}
+	for i, o := range outcomes {
+		if r.Outcome.MatchString(o.Title) {
+			return true, i
+		}
+	}
+	return false, -1
+}
+
+// Apply returns a copy of settings with the rule's overrides applied.
+func (r *TitleRule) Apply(settings *BetSettings) *BetSettings {
+	applied := *settings
+	if r.Strategy != nil {
+		applied.Strategy = *r.Strategy
+	}
+	if r.Percentage != nil {
+		applied.Percentage = *r.Percentage
+	}
+	return &applied
+}
+
+// MatchTitleRule returns the first title rule that fires for a prediction,
+// along with the index of the outcome it forces (-1 if none).
+func (bs *BetSettings) MatchTitleRule(title string, outcomes []Outcome) (*TitleRule, int) {
+	for i := range bs.TitleRules {
+		if ok, forced := bs.TitleRules[i].Match(title, outcomes); ok {
+			return &bs.TitleRules[i], forced
+		}
+	}
+	return nil, -1
+}
+
+func regexpString(re *regexp.Regexp) string {
+	if re == nil {
+		return ""
+	}
+	return re.String()
+}
@@ -0,0 +1,252 @@
+package model
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Guliveer/twitch-miner-go/internal/constants"
+)
+
+// kellyBet returns a bet that always chooses the first outcome and sizes it
+// with KELLY.
+func kellyBet(fraction float64, minimum int, outcomes ...Outcome) *Bet {
+	settings := DefaultBetSettings()
+	settings.Strategy = StrategyNumber1
+	settings.Sizing = SizingKelly
+	settings.KellyFraction = fraction
+	settings.MinimumPoints = minimum
+	settings.MaxPoints = 1_000_000
+	return NewBet(outcomes, settings)
+}
+
+func TestKellyAmount(t *testing.T) {
+	tests := []struct {
+		name     string
+		fraction float64
+		minimum  int
+		outcome  Outcome
+		want     int
+	}{
+		{
+			name:     "positive edge",
+			fraction: 1,
+			outcome:  Outcome{ID: "a", Odds: 3, PercentageUsers: 50},
+			want:     2500, // f* = 0.5 - 0.5/2
+		},
+		{
+			name:     "positive edge, half Kelly",
+			fraction: 0.5,
+			outcome:  Outcome{ID: "a", Odds: 3, PercentageUsers: 50},
+			want:     1250,
+		},
+		{
+			name:     "positive edge above minimum points",
+			fraction: 1,
+			minimum:  2000,
+			outcome:  Outcome{ID: "a", Odds: 3, PercentageUsers: 50},
+			want:     2000, // 0.25 of the 8000 above the minimum
+		},
+		{
+			name:     "zero edge",
+			fraction: 1,
+			outcome:  Outcome{ID: "a", Odds: 2, PercentageUsers: 50},
+			want:     0,
+		},
+		{
+			name:     "negative edge",
+			fraction: 1,
+			outcome:  Outcome{ID: "a", Odds: 1.5, PercentageUsers: 40},
+			want:     0,
+		},
+		{
+			name:     "no net odds",
+			fraction: 1,
+			outcome:  Outcome{ID: "a", Odds: 1, PercentageUsers: 90},
+			want:     0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bet := kellyBet(tt.fraction, tt.minimum, tt.outcome, Outcome{ID: "b", Odds: 2, PercentageUsers: 50})
+			decision := bet.Calculate(10000)
+			if decision.Choice != 0 {
+				t.Fatalf("Choice = %d, want 0", decision.Choice)
+			}
+			if decision.Amount != tt.want {
+				t.Errorf("Amount = %d, want %d", decision.Amount, tt.want)
+			}
+		})
+	}
+}
+
+func TestKellyWinRates(t *testing.T) {
+	rates := []float64{0.75, 0.25}
+
+	tests := []struct {
+		name    string
+		samples int
+		want    int
+	}{
+		{"no history falls back to voters", 0, 2500},
+		{"too little history falls back to voters", constants.KellyMinHistorySamples - 1, 2500},
+		{"enough history uses win rates", constants.KellyMinHistorySamples, 6250}, // f* = 0.75 - 0.25/2
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bet := kellyBet(1, 0,
+				Outcome{ID: "a", Odds: 3, PercentageUsers: 50},
+				Outcome{ID: "b", Odds: 1.5, PercentageUsers: 50})
+			bet.SetWinRates(rates, tt.samples)
+			if got := bet.Calculate(10000).Amount; got != tt.want {
+				t.Errorf("Amount = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBetDecisionBelowMinimum(t *testing.T) {
+	tests := []struct {
+		amount int
+		want   bool
+	}{
+		{0, true},
+		{constants.MinBetAmount - 1, true},
+		{constants.MinBetAmount, false},
+		{constants.MinBetAmount + 1, false},
+	}
+
+	for _, tt := range tests {
+		if got := (BetDecision{Amount: tt.amount}).BelowMinimum(); got != tt.want {
+			t.Errorf("BelowMinimum() with amount %d = %t, want %t", tt.amount, got, tt.want)
+		}
+	}
+}
+
+func TestKellyStakeBelowMinimum(t *testing.T) {
+	// 0.25 of a 30-point balance is 7 points, too small to place.
+	bet := kellyBet(1, 0,
+		Outcome{ID: "a", Odds: 3, PercentageUsers: 50},
+		Outcome{ID: "b", Odds: 1.5, PercentageUsers: 50})
+	decision := bet.Calculate(30)
+	if decision.Amount != 7 {
+		t.Fatalf("Amount = %d, want 7", decision.Amount)
+	}
+	if !decision.BelowMinimum() {
+		t.Errorf("BelowMinimum() = false, want true")
+	}
+}
+
+func TestCheckFiltersComparedValue(t *testing.T) {
+	gte := func(by OutcomeKey, value float64) FilterCondition {
+		return FilterCondition{By: by, Where: ConditionGTE, Value: value}
+	}
+
+	tests := []struct {
+		name      string
+		match     FilterMatch
+		filters   []FilterCondition
+		wantSkip  bool
+		wantValue float64
+	}{
+		{"all pass reports the last value", FilterMatchAll, []FilterCondition{gte(OutcomeKeyTotalUsers, 10), gte(OutcomeKeyOdds, 2)}, false, 3},
+		{"all, first failing rule", FilterMatchAll, []FilterCondition{gte(OutcomeKeyTotalUsers, 500), gte(OutcomeKeyOdds, 2)}, true, 100},
+		{"any, first passing rule", FilterMatchAny, []FilterCondition{gte(OutcomeKeyTotalUsers, 500), gte(OutcomeKeyOdds, 2)}, false, 3},
+		{"any, all fail reports the first", FilterMatchAny, []FilterCondition{gte(OutcomeKeyTotalUsers, 500), gte(OutcomeKeyOdds, 5)}, true, 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := DefaultBetSettings()
+			settings.Strategy = StrategyNumber1
+			settings.FilterConditions = tt.filters
+			settings.FilterMatch = tt.match
+			bet := NewBet([]Outcome{
+				{ID: "a", TotalUsers: 40, Odds: 3},
+				{ID: "b", TotalUsers: 60, Odds: 1.5},
+			}, settings)
+			bet.Calculate(1000)
+
+			skip, value := bet.Skip()
+			if skip != tt.wantSkip || value != tt.wantValue {
+				t.Errorf("Skip() = (%t, %v), want (%t, %v)", skip, value, tt.wantSkip, tt.wantValue)
+			}
+		})
+	}
+}
+
+// outcomesOf builds outcomes from voter shares and odds. Every outcome has
+// the same top predictor unless topPoints is given.
+func outcomesOf(shares, odds []float64, topPoints ...int) []Outcome {
+	outcomes := make([]Outcome, len(shares))
+	for i := range shares {
+		outcomes[i] = Outcome{
+			ID:              fmt.Sprintf("o%d", i),
+			PercentageUsers: shares[i],
+			TotalUsers:      int(shares[i]),
+			Odds:            odds[i],
+			TopPoints:       100,
+		}
+		if i < len(topPoints) {
+			outcomes[i].TopPoints = topPoints[i]
+		}
+	}
+	return outcomes
+}
+
+// tenOutcomes returns ten outcomes with the given voter shares where only
+// outcome high has long odds.
+func tenOutcomes(shares []float64, high int) []Outcome {
+	odds := make([]float64, len(shares))
+	for i := range odds {
+		odds[i] = 9
+	}
+	odds[high] = 12
+	return outcomesOf(shares, odds)
+}
+
+func TestSmartStrategies(t *testing.T) {
+	even := []float64{10, 10, 10, 10, 10, 10, 10, 10, 10, 10}
+	skewed := []float64{5, 40, 10, 5, 5, 5, 5, 5, 10, 10}
+
+	tests := []struct {
+		name     string
+		strategy Strategy
+		outcomes []Outcome
+		want     int
+	}{
+		{"SMART 2 outcomes close picks high odds", StrategySmart,
+			outcomesOf([]float64{55, 45}, []float64{1.8, 2.2}), 1},
+		{"SMART 2 outcomes apart picks most voted", StrategySmart,
+			outcomesOf([]float64{20, 80}, []float64{5, 1.25}), 1},
+		{"SMART 3 outcomes close picks high odds of all", StrategySmart,
+			outcomesOf([]float64{40, 35, 25}, []float64{2.5, 2.8, 4}), 2},
+		{"SMART 3 outcomes gap between first two only", StrategySmart,
+			outcomesOf([]float64{10, 50, 40}, []float64{10, 2, 2.5}), 1},
+		{"SMART 10 outcomes close picks high odds", StrategySmart, tenOutcomes(even, 7), 7},
+		{"SMART 10 outcomes apart picks most voted", StrategySmart, tenOutcomes(skewed, 7), 1},
+
+		{"SMART_MULTI 2 outcomes clear leader", StrategySmartMulti,
+			outcomesOf([]float64{70, 30}, []float64{1.4, 3.3}), 0},
+		{"SMART_MULTI 2 outcomes close picks best EV", StrategySmartMulti,
+			outcomesOf([]float64{55, 45}, []float64{1.7, 2.4}), 1},
+		{"SMART_MULTI 3 outcomes top predictor", StrategySmartMulti,
+			outcomesOf([]float64{40, 35, 25}, []float64{2.4, 2.9, 4}, 100, 1000, 200), 1},
+		{"SMART_MULTI 3 outcomes best EV", StrategySmartMulti,
+			outcomesOf([]float64{40, 35, 25}, []float64{2.4, 2.9, 4}), 1},
+		{"SMART_MULTI 10 outcomes clear leader", StrategySmartMulti, tenOutcomes(skewed, 7), 1},
+		{"SMART_MULTI 10 outcomes best EV", StrategySmartMulti, tenOutcomes(even, 7), 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := DefaultBetSettings()
+			settings.Strategy = tt.strategy
+			settings.PercentageGap = 20
+			if got := NewBet(tt.outcomes, settings).Calculate(10000).Choice; got != tt.want {
+				t.Errorf("Choice = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
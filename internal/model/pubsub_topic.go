@@ -23,6 +23,12 @@ const (
 	PubSubTopicCommunityMoments
 	// PubSubTopicCommunityGoals tracks community goal events.
 	PubSubTopicCommunityGoals
+	// PubSubTopicDropEvents tracks the user's drop progress and claims.
+	PubSubTopicDropEvents
+	// PubSubTopicHypeTrain tracks hype train events on a channel.
+	PubSubTopicHypeTrain
+	// PubSubTopicPolls tracks poll events on a channel.
+	PubSubTopicPolls
 )
 
 var topicNames = map[PubSubTopicType]string{
@@ -33,6 +39,9 @@ var topicNames = map[PubSubTopicType]string{
 	PubSubTopicRaid:             "raid",
 	PubSubTopicCommunityMoments: "community-moments-channel-v1",
 	PubSubTopicCommunityGoals:   "community-points-channel-v1",
+	PubSubTopicDropEvents:       "user-drop-events",
+	PubSubTopicHypeTrain:        "hype-train-events-v1",
+	PubSubTopicPolls:            "polls",
 }
 
 // String returns the Twitch topic string prefix for this topic type.
@@ -1,5 +1,10 @@
 package model
 
+import (
+	"fmt"
+	"strings"
+)
+
 // Raid represents an active raid event on a channel.
 type Raid struct {
 	RaidID string `json:"raid_id"`
@@ -21,3 +26,68 @@ func (r *Raid) Equal(other *Raid) bool {
 	}
 	return r.RaidID == other.RaidID
 }
+
+// RaidFilter decides which raids a streamer joins. Targets are matched by
+// login and categories by game name, display name or slug, all
+// case-insensitively. Empty lists and zero viewer bounds allow everything.
+type RaidFilter struct {
+	IncludeTargets []string `json:"include_targets,omitempty"`
+	ExcludeTargets []string `json:"exclude_targets,omitempty"`
+	Categories []string `json:"categories,omitempty"`
+	MinViewers int `json:"min_viewers,omitempty"`
+	MaxViewers int `json:"max_viewers,omitempty"`
+}
+
+// NeedsStream reports whether Check needs the target's live stream, i.e.
+// whether the filter looks at its category or viewer count.
+func (f *RaidFilter) NeedsStream() bool {
+	return f != nil && (len(f.Categories) > 0 || f.MinViewers > 0 || f.MaxViewers > 0)
+}
+
+// Check decides whether to join a raid into target. game and viewers
+// describe the target's stream and are only used when NeedsStream is true.
+// It returns whether to join and the reason for the decision. A nil filter
+// joins every raid.
+func (f *RaidFilter) Check(target string, game *GameInfo, viewers int) (bool, string) {
+	if f == nil {
+		return true, "no raid filter"
+	}
+	if containsFold(f.ExcludeTargets, target) {
+		return false, "target is excluded"
+	}
+	if len(f.IncludeTargets) > 0 && !containsFold(f.IncludeTargets, target) {
+		return false, "target is not included"
+	}
+	if len(f.Categories) > 0 {
+		if game == nil || game.Name == "" && game.DisplayName == "" && game.Slug == "" {
+			return false, "target category unknown"
+		}
+		if !gameIn(game, f.Categories) {
+			name := game.DisplayName
+			if name == "" {
+				name = game.Name
+			}
+			return false, fmt.Sprintf("category %s is not allowed", name)
+		}
+	}
+	if f.MinViewers > 0 && viewers < f.MinViewers {
+		return false, fmt.Sprintf("target has %d viewers, below %d", viewers, f.MinViewers)
+	}
+	if f.MaxViewers > 0 && viewers > f.MaxViewers {
+		return false, fmt.Sprintf("target has %d viewers, above %d", viewers, f.MaxViewers)
+	}
+	return true, "raid filter passed"
+}
+
+// containsFold reports whether list contains s, ignoring case.
+func containsFold(list []string, s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, item := range list {
+		if strings.EqualFold(strings.TrimSpace(item), s) {
+			return true
+		}
+	}
+	return false
+}
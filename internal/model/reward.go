@@ -0,0 +1,69 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// CustomReward is a channel points reward set up by the broadcaster.
+type CustomReward struct {
+	ID string `json:"id"`
+	Title string `json:"title"`
+	Prompt string `json:"prompt,omitempty"`
+	Cost int `json:"cost"`
+	IsEnabled bool `json:"is_enabled"`
+	IsPaused bool `json:"is_paused"`
+	IsInStock bool `json:"is_in_stock"`
+	IsUserInputRequired bool `json:"is_user_input_required"`
+	CooldownExpiresAt time.Time `json:"cooldown_expires_at,omitempty"`
+}
+
+// IsRedeemable reports whether the reward can be redeemed right now.
+func (r *CustomReward) IsRedeemable(now time.Time) bool {
+	return r.IsEnabled && !r.IsPaused && r.IsInStock && !now.Before(r.CooldownExpiresAt)
+}
+
+// String returns a human-readable representation of the reward.
+func (r *CustomReward) String() string {
+	return fmt.Sprintf("CustomReward(id=%s, title=%s, cost=%d)", r.ID, r.Title, r.Cost)
+}
+
+// RedeemRule redeems a channel's custom reward automatically. A rule fires
+// for a reward whose title matches Reward when the balance stays at or above
+// KeepBalance after paying for it, the cost is within MaxCost (if set), and
+// the reward has been redeemed fewer than MaxPerStream times this stream.
+// Rewards that need user input are only redeemed when Text is set.
+type RedeemRule struct {
+	Name string `json:"name"`
+	Reward *regexp.Regexp `json:"-"`
+	KeepBalance int `json:"keep_balance"`
+	MaxCost int `json:"max_cost,omitempty"`
+	MaxPerStream int `json:"max_per_stream"`
+	Text string `json:"text,omitempty"`
+	DryRun bool `json:"dry_run"`
+}
+
+// Match reports whether the rule applies to the reward at the given balance,
+// with redeemed being the times the reward was redeemed this stream.
+func (r *RedeemRule) Match(reward *CustomReward, balance, redeemed int) bool {
+	if r.Reward == nil || !r.Reward.MatchString(reward.Title) {
+		return false
+	}
+	if reward.IsUserInputRequired && r.Text == "" {
+		return false
+	}
+	if r.MaxCost > 0 && reward.Cost > r.MaxCost {
+		return false
+	}
+	if redeemed >= r.MaxPerStream {
+		return false
+	}
+	return balance-reward.Cost >= r.KeepBalance
+}
+
+// String returns a human-readable representation of the redeem rule.
+func (r *RedeemRule) String() string {
+	return fmt.Sprintf("RedeemRule(name=%s, reward=%s, keep_balance=%d, max_cost=%d, max_per_stream=%d, dry_run=%t)",
+		r.Name, regexpString(r.Reward), r.KeepBalance, r.MaxCost, r.MaxPerStream, r.DryRun)
+}
@@ -0,0 +1,209 @@
+package model
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Guliveer/twitch-miner-go/internal/constants"
+	"github.com/Guliveer/twitch-miner-go/internal/utils"
+)
+
+// Session summarises what the miner did during one broadcast of a streamer.
+// Sessions are keyed by the broadcast ID reported by Twitch.
+type Session struct {
+	BroadcastID string `json:"broadcast_id"`
+	StartedAt time.Time `json:"started_at"`
+	EndedAt *time.Time `json:"ended_at,omitempty"`
+	Categories []SessionCategory `json:"categories"`
+	MinutesWatched float64 `json:"minutes_watched"`
+	Points map[string]int `json:"points"` // Reason code → points earned (negative if spent)
+	BetsPlaced int `json:"bets_placed"`
+	BetsWon int `json:"bets_won"`
+	BonusesClaimed int `json:"bonuses_claimed"`
+	WatchStreak int `json:"watch_streak"` // Points earned from the watch streak bonus
+}
+
+// SessionCategory records the category a broadcast switched to and when.
+type SessionCategory struct {
+	Name string `json:"name"`
+	Since time.Time `json:"since"`
+}
+
+// NewSession creates a session for a broadcast that started at startedAt.
+func NewSession(broadcastID string, startedAt time.Time) *Session {
+	return &Session{
+		BroadcastID: broadcastID,
+		StartedAt:   startedAt,
+		Categories:  make([]SessionCategory, 0),
+		Points:      make(map[string]int),
+	}
+}
+
+// IsActive reports whether the session's broadcast is still running.
+func (s *Session) IsActive() bool {
+	return s.EndedAt == nil
+}
+
+// SetCategory records a category change. Repeating the current category or
+// passing an empty name is a no-op.
+func (s *Session) SetCategory(name string, at time.Time) {
+	if name == "" {
+		return
+	}
+	if n := len(s.Categories); n > 0 && s.Categories[n-1].Name == name {
+		return
+	}
+	s.Categories = append(s.Categories, SessionCategory{Name: name, Since: at})
+}
+
+// End marks the session as finished at endedAt.
+func (s *Session) End(endedAt time.Time) {
+	if s.EndedAt == nil {
+		s.EndedAt = &endedAt
+	}
+}
+
+// Duration returns how long the broadcast has been followed, up to now for
+// an active session.
+func (s *Session) Duration(now time.Time) time.Duration {
+	if s.EndedAt != nil {
+		now = *s.EndedAt
+	}
+	return now.Sub(s.StartedAt)
+}
+
+// TotalPoints returns the net points of the session over all reasons.
+func (s *Session) TotalPoints() int {
+	total := 0
+	for _, amount := range s.Points {
+		total += amount
+	}
+	return total
+}
+
+// Clone returns a deep copy of the session.
+func (s *Session) Clone() *Session {
+	clone := *s
+	if s.EndedAt != nil {
+		endedAt := *s.EndedAt
+		clone.EndedAt = &endedAt
+	}
+	clone.Categories = slices.Clone(s.Categories)
+	clone.Points = maps.Clone(s.Points)
+	return &clone
+}
+
+// Summary returns a compact one-line digest of the session for notifiers.
+func (s *Session) Summary(now time.Time) string {
+	points := utils.Millify(s.TotalPoints(), 2)
+	if s.TotalPoints() >= 0 {
+		points = "+" + points
+	}
+	parts := []string{
+		s.Duration(now).Round(time.Minute).String(),
+		fmt.Sprintf("%d min watched", int(s.MinutesWatched)),
+		points + " points",
+	}
+	if s.BetsPlaced > 0 {
+		parts = append(parts, fmt.Sprintf("%d/%d bets won", s.BetsWon, s.BetsPlaced))
+	}
+	if s.BonusesClaimed > 0 {
+		parts = append(parts, fmt.Sprintf("%d bonuses", s.BonusesClaimed))
+	}
+	if s.WatchStreak > 0 {
+		parts = append(parts, fmt.Sprintf("streak +%d", s.WatchStreak))
+	}
+	if len(s.Categories) > 0 {
+		names := make([]string, len(s.Categories))
+		for i, category := range s.Categories {
+			names[i] = category.Name
+		}
+		parts = append(parts, strings.Join(names, " → "))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// CurrentSession returns the streamer's active session, or nil.
+// Must be called with Mu held (at least RLock).
+func (s *Streamer) CurrentSession() *Session {
+	if n := len(s.Sessions); n > 0 && s.Sessions[n-1].IsActive() {
+		return s.Sessions[n-1]
+	}
+	return nil
+}
+
+// SessionFor returns the session of the given broadcast, active or ended,
+// or nil if it is no longer kept. Must be called with Mu held (at least RLock).
+func (s *Streamer) SessionFor(broadcastID string) *Session {
+	if broadcastID == "" {
+		return nil
+	}
+	for i := len(s.Sessions) - 1; i >= 0; i-- {
+		if s.Sessions[i].BroadcastID == broadcastID {
+			return s.Sessions[i]
+		}
+	}
+	return nil
+}
+
+// TrackSession keeps the session list in line with the current stream info.
+// A new broadcast ID ends the previous session and starts a new one. The
+// broadcast ID of the last ended session reopens it instead (the stream-down
+// was premature). A new category is recorded on the active session. Only the
+// most recent MaxStreamerSessions sessions are kept. Missed watch streaks are
+// detected from the persisted Streak, so a broadcast seen before a restart
+// still counts; see StreakState.See. Must be called with Mu held.
+func (s *Streamer) TrackSession(now time.Time) {
+	if s.Stream == nil || s.Stream.BroadcastID == "" {
+		return
+	}
+
+	s.Streak.See(s.Stream.BroadcastID, s.Settings != nil && s.Settings.WatchStreak, now)
+
+	session := s.CurrentSession()
+	if session != nil && session.BroadcastID != s.Stream.BroadcastID {
+		session.End(now)
+		session = nil
+	}
+	if n := len(s.Sessions); session == nil && n > 0 && s.Sessions[n-1].BroadcastID == s.Stream.BroadcastID {
+		session = s.Sessions[n-1]
+		session.EndedAt = nil
+	}
+	if session == nil {
+		session = NewSession(s.Stream.BroadcastID, now)
+		s.Sessions = append(s.Sessions, session)
+		if excess := len(s.Sessions) - constants.MaxStreamerSessions; excess > 0 {
+			s.Sessions = slices.Delete(s.Sessions, 0, excess)
+		}
+	}
+
+	category := s.Stream.GameDisplayName()
+	if category == "" {
+		category = s.Stream.GameName()
+	}
+	session.SetCategory(category, now)
+}
+
+// EndSession ends the active session and returns a copy of it, or nil if
+// there is none. Must be called with Mu held.
+func (s *Streamer) EndSession(now time.Time) *Session {
+	session := s.CurrentSession()
+	if session == nil {
+		return nil
+	}
+	session.End(now)
+	return session.Clone()
+}
+
+// SessionsSnapshot returns deep copies of the streamer's sessions, newest
+// first. Must be called with Mu held (at least RLock).
+func (s *Streamer) SessionsSnapshot() []*Session {
+	result := make([]*Session, 0, len(s.Sessions))
+	for i := len(s.Sessions) - 1; i >= 0; i-- {
+		result = append(result, s.Sessions[i].Clone())
+	}
+	return result
+}
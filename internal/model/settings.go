@@ -7,23 +7,33 @@ type Event string
 const (
 	EventStreamerOnline      Event = "STREAMER_ONLINE"
 	EventStreamerOffline     Event = "STREAMER_OFFLINE"
+	EventSessionSummary      Event = "SESSION_SUMMARY"
 	EventGainForRaid        Event = "GAIN_FOR_RAID"
 	EventGainForClaim       Event = "GAIN_FOR_CLAIM"
 	EventGainForWatch       Event = "GAIN_FOR_WATCH"
 	EventGainForWatchStreak Event = "GAIN_FOR_WATCH_STREAK"
+	EventWatchStreakAtRisk  Event = "WATCH_STREAK_AT_RISK"
 	EventBetWin             Event = "BET_WIN"
 	EventBetLose            Event = "BET_LOSE"
 	EventBetRefund          Event = "BET_REFUND"
 	EventBetFilters         Event = "BET_FILTERS"
 	EventBetGeneral         Event = "BET_GENERAL"
 	EventBetFailed          Event = "BET_FAILED"
+	EventBetUnconfirmed     Event = "BET_UNCONFIRMED"
 	EventBetStart           Event = "BET_START"
+	EventBetLocked          Event = "BET_LOCKED"
 	EventBonusClaim         Event = "BONUS_CLAIM"
 	EventMomentClaim        Event = "MOMENT_CLAIM"
+	EventRewardRedeem       Event = "REWARD_REDEEM"
+	EventGoalContribute     Event = "GOAL_CONTRIBUTE"
 	EventJoinRaid           Event = "JOIN_RAID"
+	EventRaidSkipped        Event = "RAID_SKIPPED"
 	EventDropClaim          Event = "DROP_CLAIM"
 	EventDropStatus         Event = "DROP_STATUS"
 	EventChatMention        Event = "CHAT_MENTION"
+	EventHypeTrainStart     Event = "HYPE_TRAIN_START"
+	EventHypeTrainLevel     Event = "HYPE_TRAIN_LEVEL"
+	EventPollStart          Event = "POLL_START"
 	EventTest               Event = "TEST"
 )
 
@@ -32,23 +42,33 @@ func AllEvents() []Event {
 	return []Event{
 		EventStreamerOnline,
 		EventStreamerOffline,
+		EventSessionSummary,
 		EventGainForRaid,
 		EventGainForClaim,
 		EventGainForWatch,
 		EventGainForWatchStreak,
+		EventWatchStreakAtRisk,
 		EventBetWin,
 		EventBetLose,
 		EventBetRefund,
 		EventBetFilters,
 		EventBetGeneral,
 		EventBetFailed,
+		EventBetUnconfirmed,
 		EventBetStart,
+		EventBetLocked,
 		EventBonusClaim,
 		EventMomentClaim,
+		EventRewardRedeem,
+		EventGoalContribute,
 		EventJoinRaid,
+		EventRaidSkipped,
 		EventDropClaim,
 		EventDropStatus,
 		EventChatMention,
+		EventHypeTrainStart,
+		EventHypeTrainLevel,
+		EventPollStart,
 		EventTest,
 	}
 }
@@ -0,0 +1,58 @@
+package model
+
+import "time"
+
+// StreakState tracks a streamer's watch streak across broadcasts. It is
+// persisted per account so it survives restarts.
+type StreakState struct {
+	Value int `json:"value"`
+	LastBroadcastID string `json:"last_broadcast_id,omitempty"` // Last broadcast the streak bonus was earned on
+	LastSeenBroadcastID string `json:"last_seen_broadcast_id,omitempty"` // Last broadcast the miner saw
+	LastEarnedAt time.Time `json:"last_earned_at"`
+	BroadcastsMissed int `json:"broadcasts_missed"` // Broadcasts since LastBroadcastID without the bonus
+	BrokenAt time.Time `json:"broken_at"`
+	BrokenValue int `json:"broken_value,omitempty"` // Streak lost at BrokenAt
+}
+
+// Earn records the streak bonus for a broadcast. The streak length is
+// counted locally: each broadcast the bonus is earned on adds one.
+func (st *StreakState) Earn(broadcastID string, at time.Time) {
+	if broadcastID == "" || broadcastID != st.LastBroadcastID {
+		st.Value++
+		st.LastBroadcastID = broadcastID
+	}
+	st.LastEarnedAt = at
+	st.BroadcastsMissed = 0
+}
+
+// Miss records a finished broadcast the streak bonus was not earned on.
+// The first miss breaks a running streak.
+func (st *StreakState) Miss(at time.Time) {
+	st.BroadcastsMissed++
+	if st.Value > 0 {
+		st.BrokenAt = at
+		st.BrokenValue = st.Value
+		st.Value = 0
+	}
+}
+
+// See records that broadcastID is live. When it replaces a previously seen
+// broadcast the streak bonus was not earned on, that broadcast is counted as
+// missed if counted is true. It reports whether a miss was recorded.
+func (st *StreakState) See(broadcastID string, counted bool, at time.Time) bool {
+	if broadcastID == "" || broadcastID == st.LastSeenBroadcastID {
+		return false
+	}
+	previous := st.LastSeenBroadcastID
+	st.LastSeenBroadcastID = broadcastID
+	if previous == "" || previous == st.LastBroadcastID || !counted {
+		return false
+	}
+	st.Miss(at)
+	return true
+}
+
+// IsZero reports whether no streak has been recorded.
+func (st *StreakState) IsZero() bool {
+	return *st == StreakState{}
+}
@@ -149,14 +149,17 @@ func (s *Stream) InitWatchStreak() {
 	s.minuteWatchedTimestamp = time.Time{}
 }
 
-// UpdateMinuteWatched increments the minute-watched counter based on elapsed time.
-func (s *Stream) UpdateMinuteWatched() {
+// UpdateMinuteWatched increments the minute-watched counter based on elapsed
+// time and returns the minutes added.
+func (s *Stream) UpdateMinuteWatched() float64 {
 	now := time.Now()
+	elapsed := 0.0
 	if !s.minuteWatchedTimestamp.IsZero() {
-		elapsed := now.Sub(s.minuteWatchedTimestamp).Minutes()
+		elapsed = now.Sub(s.minuteWatchedTimestamp).Minutes()
 		s.MinuteWatched += elapsed
 	}
 	s.minuteWatchedTimestamp = now
+	return elapsed
 }
 
 // String returns a human-readable representation of the stream.
@@ -22,6 +22,9 @@ type Streamer struct {
 	IsOnline bool `json:"is_online"`
 	IsCategoryWatched bool `json:"is_category_watched"`
 	CategorySlug string `json:"category_slug,omitempty"`
+	DropsCampaignID string `json:"drops_campaign_id,omitempty"` // Set when added by the drops watcher
+	RaidFrom string `json:"raid_from,omitempty"` // Set when followed as a raid target; the raiding streamer
+	RaidTargetExpiresAt time.Time `json:"-"`
 
 	StreamUpAt time.Time `json:"stream_up_at"`
 	OnlineAt time.Time `json:"online_at"`
@@ -30,6 +33,11 @@ type Streamer struct {
 	ChannelPoints int `json:"channel_points"`
 
 	CommunityGoals map[string]*CommunityGoal `json:"community_goals,omitempty"`
+	GoalContributions map[string]int `json:"goal_contributions,omitempty"` // Goal ID → points contributed by the miner
+
+	CustomRewards []CustomReward `json:"custom_rewards,omitempty"`
+	RewardRedemptions map[string]int `json:"reward_redemptions,omitempty"` // Reward ID → redemptions this stream
+	PendingRedemptions map[string]PendingRedemption `json:"-"` // Reward ID → redemption that has not succeeded yet
 
 	ViewerIsMod bool `json:"viewer_is_mod"`
 	ActiveMultipliers []PointsMultiplier `json:"active_multipliers,omitempty"`
@@ -39,6 +47,8 @@ type Streamer struct {
 	Raid *Raid `json:"raid,omitempty"`
 
 	History map[string]*HistoryEntry `json:"history,omitempty"`
+	Sessions []*Session `json:"-"` // Oldest first; served by the sessions endpoint
+	Streak StreakState `json:"streak"`
 
 	StreamerURL string `json:"streamer_url"`
 }
@@ -79,6 +89,8 @@ func (s *Streamer) SetOnline() {
 		s.OnlineAt = time.Now()
 		s.IsOnline = true
 		s.Stream.InitWatchStreak()
+		s.RewardRedemptions = nil
+		s.PendingRedemptions = nil
 	}
 }
 
@@ -90,6 +102,16 @@ func (s *Streamer) UpdateHistory(reasonCode string, earned int, counter int) {
 	s.History[reasonCode].Counter += counter
 	s.History[reasonCode].Amount += earned
 
+	if session := s.CurrentSession(); session != nil {
+		session.Points[reasonCode] += earned
+		switch reasonCode {
+		case "CLAIM":
+			session.BonusesClaimed += counter
+		case "WATCH_STREAK":
+			session.WatchStreak += earned
+		}
+	}
+
 	if reasonCode == "WATCH_STREAK" {
 		s.Stream.WatchStreakMissing = false
 	}
@@ -148,9 +170,50 @@ func (s *Streamer) UpdateCommunityGoal(goal *CommunityGoal) {
 	s.CommunityGoals[goal.GoalID] = goal
 }
 
-// DeleteCommunityGoal removes a community goal by ID.
+// PendingRedemption is a reward redemption that has been attempted but not
+// confirmed. Retries reuse its transaction ID so Twitch can drop a duplicate
+// of an attempt that did go through.
+type PendingRedemption struct {
+	TransactionID string
+	Failures int
+}
+
+// RecordRedemption counts a reward redemption towards the current stream.
+func (s *Streamer) RecordRedemption(rewardID string) {
+	if s.RewardRedemptions == nil {
+		s.RewardRedemptions = make(map[string]int)
+	}
+	s.RewardRedemptions[rewardID]++
+	delete(s.PendingRedemptions, rewardID)
+}
+
+// RecordRedemptionFailure records a failed attempt to redeem a reward with
+// the given transaction ID and returns the failures so far this stream.
+func (s *Streamer) RecordRedemptionFailure(rewardID, transactionID string) int {
+	if s.PendingRedemptions == nil {
+		s.PendingRedemptions = make(map[string]PendingRedemption)
+	}
+	pending := s.PendingRedemptions[rewardID]
+	pending.TransactionID = transactionID
+	pending.Failures++
+	s.PendingRedemptions[rewardID] = pending
+	return pending.Failures
+}
+
+// RecordGoalContribution records points contributed to a community goal.
+func (s *Streamer) RecordGoalContribution(goalID string, amount int) {
+	if s.GoalContributions == nil {
+		s.GoalContributions = make(map[string]int)
+	}
+	s.GoalContributions[goalID] += amount
+	s.UpdateHistory("COMMUNITY_GOAL", -amount, 1)
+}
+
+// DeleteCommunityGoal removes a community goal and the points contributed
+// to it by ID.
 func (s *Streamer) DeleteCommunityGoal(goalID string) {
 	delete(s.CommunityGoals, goalID)
+	delete(s.GoalContributions, goalID)
 }
 
 // String returns a human-readable representation of the streamer.
@@ -236,8 +299,13 @@ type StreamerSettings struct {
 	ClaimMoments bool `json:"claim_moments" yaml:"claim_moments"`
 	WatchStreak bool `json:"watch_streak" yaml:"watch_streak"`
 	CommunityGoalsEnabled bool `json:"community_goals" yaml:"community_goals"`
+	HypeTrain bool `json:"hype_train" yaml:"hype_train"`
+	Polls bool `json:"polls" yaml:"polls"`
 	Bet *BetSettings `json:"bet,omitempty" yaml:"bet"`
 	Chat ChatPresence `json:"chat" yaml:"chat"`
+	RedeemRules []RedeemRule `json:"redeem,omitempty" yaml:"-"`
+	GoalPolicy *GoalPolicy `json:"goals,omitempty" yaml:"-"`
+	RaidFilter *RaidFilter `json:"raid,omitempty" yaml:"-"`
 }
 
 // DefaultStreamerSettings returns StreamerSettings with default values.
@@ -249,6 +317,8 @@ func DefaultStreamerSettings() *StreamerSettings {
 		ClaimMoments:          true,
 		WatchStreak:           true,
 		CommunityGoalsEnabled: false,
+		HypeTrain:             false,
+		Polls:                 false,
 		Bet:                   DefaultBetSettings(),
 		Chat:                  ChatOnline,
 	}
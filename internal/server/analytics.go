@@ -24,6 +24,18 @@ type StreamerFunc func() []*model.Streamer
 // notifiers across all miners. Returns any errors encountered.
 type NotifyTestFunc func(ctx context.Context) []error
 
+// AccountDrops is one account's drop campaigns together with the streamers
+// that can progress them.
+type AccountDrops struct {
+	Account   string
+	Campaigns []*model.Campaign
+	Streamers []*model.Streamer
+	Watching  []string // usernames currently receiving minute-watched events
+}
+
+// DropsFunc is a function that returns the drop campaigns of every miner.
+type DropsFunc func() []AccountDrops
+
 // AnalyticsServer serves the analytics dashboard and JSON API endpoints.
 type AnalyticsServer struct {
 	addr string
@@ -34,6 +46,7 @@ type AnalyticsServer struct {
 	streamers      []*model.Streamer
 	streamerFunc   StreamerFunc
 	notifyTestFunc NotifyTestFunc
+	dropsFunc      DropsFunc
 }
 
 // NewAnalyticsServer creates a new AnalyticsServer bound to the given address.
@@ -49,12 +62,15 @@ func NewAnalyticsServer(addr string, log *logger.Logger) *AnalyticsServer {
 	mux.HandleFunc("GET /health", s.handleHealth)
 	mux.HandleFunc("GET /api/streamers", s.handleStreamers)
 	mux.HandleFunc("GET /api/streamer/{name}", s.handleStreamer)
+	mux.HandleFunc("GET /api/streamer/{name}/sessions", s.handleStreamerSessions)
 	mux.HandleFunc("GET /api/stats", s.handleStats)
 	mux.HandleFunc("GET /api/filters", s.handleFilters)
 	mux.HandleFunc("GET /api/events", s.handleEventLogs)
 	mux.HandleFunc("GET /api/event-filters", s.handleEventFilters)
+	mux.HandleFunc("GET /api/drops", s.handleDrops)
 
 	mux.HandleFunc("POST /api/test-notification", s.handleTestNotification)
+	mux.HandleFunc("POST /api/bet/simulate", s.handleBetSimulate)
 	mux.Handle("GET /static/", http.StripPrefix("/static/", http.FileServerFS(staticFS)))
 
 	// pprof endpoints for remote memory profiling
@@ -97,6 +113,14 @@ func (s *AnalyticsServer) SetNotifyTestFunc(fn NotifyTestFunc) {
 	s.mu.Unlock()
 }
 
+// SetDropsFunc sets a function that returns the drop campaigns of every
+// miner. Thread-safe.
+func (s *AnalyticsServer) SetDropsFunc(fn DropsFunc) {
+	s.mu.Lock()
+	s.dropsFunc = fn
+	s.mu.Unlock()
+}
+
 // SetStreamerFunc sets a function that dynamically returns all streamers
 // across all miners. When set, getStreamers() calls this function instead
 // of returning the static list.
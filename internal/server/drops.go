@@ -0,0 +1,157 @@
+package server
+
+import (
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Guliveer/twitch-miner-go/internal/model"
+)
+
+type accountDropsResponse struct {
+	Account   string             `json:"account"`
+	Campaigns []campaignProgress `json:"campaigns"`
+}
+
+type campaignProgress struct {
+	ID          string         `json:"id"`
+	Name        string         `json:"name"`
+	Game        string         `json:"game,omitempty"`
+	EndAt       time.Time      `json:"end_at"`
+	InInventory bool           `json:"in_inventory"`
+	Streamer    string         `json:"streamer,omitempty"`
+	Available   []string       `json:"available_streamers"`
+	Drops       []dropProgress `json:"drops"`
+}
+
+type dropProgress struct {
+	ID                    string     `json:"id"`
+	Name                  string     `json:"name"`
+	Benefit               string     `json:"benefit"`
+	MinutesRequired       int        `json:"minutes_required"`
+	CurrentMinutesWatched int        `json:"current_minutes_watched"`
+	RemainingMinutes      int        `json:"remaining_minutes"`
+	Progress              int        `json:"progress"`
+	EndAt                 time.Time  `json:"end_at"`
+	ETA                   *time.Time `json:"eta,omitempty"`
+	Achievable            bool       `json:"achievable"`
+	Claimed               bool       `json:"claimed"`
+	Claimable             bool       `json:"claimable"`
+}
+
+// handleDrops lists the active drop campaigns of every account with per-drop
+// progress. The ETA assumes the progressing streamer stays in a watch slot;
+// a drop is achievable if its remaining minutes fit before its deadline.
+func (s *AnalyticsServer) handleDrops(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	fn := s.dropsFunc
+	s.mu.RUnlock()
+
+	if fn == nil {
+		writeJSON(w, http.StatusOK, []accountDropsResponse{})
+		return
+	}
+
+	accountFilter := r.URL.Query().Get("account")
+	now := time.Now()
+
+	result := make([]accountDropsResponse, 0)
+	for _, account := range fn() {
+		if accountFilter != "" && !strings.EqualFold(account.Account, accountFilter) {
+			continue
+		}
+
+		entry := accountDropsResponse{
+			Account:   account.Account,
+			Campaigns: make([]campaignProgress, 0, len(account.Campaigns)),
+		}
+		for _, campaign := range account.Campaigns {
+			entry.Campaigns = append(entry.Campaigns, buildCampaignProgress(campaign, account, now))
+		}
+		sort.Slice(entry.Campaigns, func(i, j int) bool {
+			return entry.Campaigns[i].EndAt.Before(entry.Campaigns[j].EndAt)
+		})
+		result = append(result, entry)
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func buildCampaignProgress(campaign *model.Campaign, account AccountDrops, now time.Time) campaignProgress {
+	progress := campaignProgress{
+		ID:          campaign.ID,
+		Name:        campaign.Name,
+		EndAt:       campaign.EndAt,
+		InInventory: campaign.InInventory,
+		Available:   make([]string, 0),
+		Drops:       make([]dropProgress, 0, len(campaign.Drops)+len(campaign.ClaimedDrops)),
+	}
+	if campaign.Game != nil {
+		progress.Game = campaign.Game.DisplayName
+	}
+
+	for _, streamer := range account.Streamers {
+		streamer.Mu.RLock()
+		carries := streamer.IsOnline && streamer.Stream != nil &&
+			slices.ContainsFunc(streamer.Stream.Campaigns, func(c model.Campaign) bool { return c.ID == campaign.ID })
+		username := streamer.Username
+		streamer.Mu.RUnlock()
+
+		if !carries {
+			continue
+		}
+		progress.Available = append(progress.Available, username)
+		if progress.Streamer == "" && slices.Contains(account.Watching, username) {
+			progress.Streamer = username
+		}
+	}
+	sort.Strings(progress.Available)
+
+	for _, drop := range campaign.Drops {
+		progress.Drops = append(progress.Drops, buildDropProgress(drop, campaign, progress.Streamer != "", now))
+	}
+	for _, drop := range campaign.ClaimedDrops {
+		progress.Drops = append(progress.Drops, buildDropProgress(drop, campaign, false, now))
+	}
+	sort.SliceStable(progress.Drops, func(i, j int) bool {
+		return progress.Drops[i].MinutesRequired < progress.Drops[j].MinutesRequired
+	})
+
+	return progress
+}
+
+func buildDropProgress(drop *model.Drop, campaign *model.Campaign, progressing bool, now time.Time) dropProgress {
+	remaining := max(drop.MinutesRequired-drop.CurrentMinutesWatched, 0)
+	if drop.IsClaimed {
+		remaining = 0
+	}
+
+	deadline := drop.EndAt
+	if deadline.IsZero() || campaign.EndAt.Before(deadline) {
+		deadline = campaign.EndAt
+	}
+
+	progress := dropProgress{
+		ID:                    drop.ID,
+		Name:                  drop.Name,
+		Benefit:               drop.Benefit,
+		MinutesRequired:       drop.MinutesRequired,
+		CurrentMinutesWatched: drop.CurrentMinutesWatched,
+		RemainingMinutes:      remaining,
+		Progress:              min(model.Percentage(drop.CurrentMinutesWatched, drop.MinutesRequired), 100),
+		EndAt:                 deadline,
+		Achievable:            drop.IsClaimed || !now.Add(time.Duration(remaining)*time.Minute).After(deadline),
+		Claimed:               drop.IsClaimed,
+		Claimable:             drop.IsClaimable,
+	}
+	if drop.IsClaimed {
+		progress.Progress = 100
+	}
+	if progressing && !drop.IsClaimed {
+		eta := now.Add(time.Duration(remaining) * time.Minute)
+		progress.ETA = &eta
+	}
+	return progress
+}
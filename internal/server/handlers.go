@@ -100,6 +100,7 @@ func (s *AnalyticsServer) handleStreamers(w http.ResponseWriter, r *http.Request
 			IsCategoryWatched: streamer.IsCategoryWatched,
 			ChannelPoints:     streamer.ChannelPoints,
 			StreamerURL:       streamer.StreamerURL,
+			Streak:            streamer.Streak,
 		}
 		if streamer.Stream != nil && streamer.Stream.Game != nil {
 			summary.Game = streamer.Stream.Game.DisplayName
@@ -138,6 +139,7 @@ func (s *AnalyticsServer) handleStreamer(w http.ResponseWriter, r *http.Request)
 				StreamerURL:       streamer.StreamerURL,
 				ViewerIsMod:       streamer.ViewerIsMod,
 				History:           streamer.History,
+				Streak:            streamer.Streak,
 			}
 			if streamer.Stream != nil {
 				detail.Stream = &streamInfo{
@@ -166,6 +168,43 @@ func (s *AnalyticsServer) handleStreamer(w http.ResponseWriter, r *http.Request)
 	writeJSON(w, http.StatusNotFound, errorResponse{Error: "streamer not found"})
 }
 
+type streamerSessions struct {
+	Account  string           `json:"account"`
+	Username string           `json:"username"`
+	Sessions []*model.Session `json:"sessions"`
+}
+
+// handleStreamerSessions lists a streamer's broadcast sessions, newest first.
+// When several accounts watch the streamer, ?account= picks one; otherwise
+// the first account is used.
+func (s *AnalyticsServer) handleStreamerSessions(w http.ResponseWriter, r *http.Request) {
+	name := strings.ToLower(r.PathValue("name"))
+	if name == "" {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "missing streamer name"})
+		return
+	}
+	accountFilter := r.URL.Query().Get("account")
+
+	for _, streamer := range s.getStreamers() {
+		streamer.Mu.RLock()
+		if strings.ToLower(streamer.Username) != name ||
+			(accountFilter != "" && !strings.EqualFold(streamer.AccountUsername, accountFilter)) {
+			streamer.Mu.RUnlock()
+			continue
+		}
+		result := streamerSessions{
+			Account:  streamer.AccountUsername,
+			Username: streamer.Username,
+			Sessions: streamer.SessionsSnapshot(),
+		}
+		streamer.Mu.RUnlock()
+		writeJSON(w, http.StatusOK, result)
+		return
+	}
+
+	writeJSON(w, http.StatusNotFound, errorResponse{Error: "streamer not found"})
+}
+
 func (s *AnalyticsServer) handleStats(w http.ResponseWriter, r *http.Request) {
 	streamers := filterStreamers(s.getStreamers(), r)
 
@@ -239,11 +278,11 @@ func (s *AnalyticsServer) handleFilters(w http.ResponseWriter, _ *http.Request)
 // Event category groups for filtering on the logs page.
 var eventCategories = map[string][]string{
 	"drops":   {"DROP_CLAIM", "DROP_STATUS"},
-	"points":  {"GAIN_FOR_WATCH", "GAIN_FOR_WATCH_STREAK", "GAIN_FOR_CLAIM", "GAIN_FOR_RAID", "BONUS_CLAIM"},
-	"bets":    {"BET_START", "BET_WIN", "BET_LOSE", "BET_REFUND", "BET_FILTERS", "BET_GENERAL", "BET_FAILED"},
-	"raids":   {"JOIN_RAID"},
-	"streams": {"STREAMER_ONLINE", "STREAMER_OFFLINE"},
-	"other":   {"MOMENT_CLAIM", "CHAT_MENTION"},
+	"points":  {"GAIN_FOR_WATCH", "GAIN_FOR_WATCH_STREAK", "WATCH_STREAK_AT_RISK", "GAIN_FOR_CLAIM", "GAIN_FOR_RAID", "BONUS_CLAIM", "REWARD_REDEEM", "GOAL_CONTRIBUTE"},
+	"bets":    {"BET_START", "BET_WIN", "BET_LOSE", "BET_REFUND", "BET_FILTERS", "BET_GENERAL", "BET_FAILED", "BET_UNCONFIRMED", "BET_LOCKED"},
+	"raids":   {"JOIN_RAID", "RAID_SKIPPED"},
+	"streams": {"STREAMER_ONLINE", "STREAMER_OFFLINE", "SESSION_SUMMARY"},
+	"other":   {"MOMENT_CLAIM", "CHAT_MENTION", "HYPE_TRAIN_START", "HYPE_TRAIN_LEVEL", "POLL_START"},
 }
 
 type eventLogEntry struct {
@@ -350,17 +389,18 @@ func sortedKeys(m map[string]bool) []string {
 }
 
 type streamerSummary struct {
-	Account           string `json:"account"`
-	Username          string `json:"username"`
-	DisplayName       string `json:"display_name,omitempty"`
-	ChannelID         string `json:"channel_id"`
-	IsOnline          bool   `json:"is_online"`
-	IsCategoryWatched bool   `json:"is_category_watched"`
-	ChannelPoints     int    `json:"channel_points"`
-	StreamerURL       string `json:"streamer_url"`
-	Game              string `json:"game,omitempty"`
-	ViewersCount      int    `json:"viewers_count"`
-	Title             string `json:"title,omitempty"`
+	Account           string            `json:"account"`
+	Username          string            `json:"username"`
+	DisplayName       string            `json:"display_name,omitempty"`
+	ChannelID         string            `json:"channel_id"`
+	IsOnline          bool              `json:"is_online"`
+	IsCategoryWatched bool              `json:"is_category_watched"`
+	ChannelPoints     int               `json:"channel_points"`
+	StreamerURL       string            `json:"streamer_url"`
+	Game              string            `json:"game,omitempty"`
+	ViewersCount      int               `json:"viewers_count"`
+	Title             string            `json:"title,omitempty"`
+	Streak            model.StreakState `json:"streak"`
 }
 
 type streamerDetail struct {
@@ -377,6 +417,7 @@ type streamerDetail struct {
 	Stream            *streamInfo                    `json:"stream,omitempty"`
 	Multipliers       []float64                      `json:"multipliers,omitempty"`
 	History           map[string]*model.HistoryEntry `json:"history,omitempty"`
+	Streak            model.StreakState              `json:"streak"`
 }
 
 type streamInfo struct {
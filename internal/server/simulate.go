@@ -0,0 +1,153 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Guliveer/twitch-miner-go/internal/config"
+	"github.com/Guliveer/twitch-miner-go/internal/model"
+)
+
+const (
+	// maxSimulateBodyBytes caps the size of a bet simulation request.
+	maxSimulateBodyBytes = 64 << 10
+	// maxSimulateOutcomes matches the most outcomes a Twitch prediction can have.
+	maxSimulateOutcomes = 10
+)
+
+// simulateRequest is the body of POST /api/bet/simulate. Settings uses the
+// same keys as the bet block of an account config.
+type simulateRequest struct {
+	Balance int `json:"balance"`
+	SecondsLeft float64 `json:"seconds_left"`
+	Outcomes []model.Outcome `json:"outcomes"`
+	Settings json.RawMessage `json:"settings"`
+}
+
+type simulateResponse struct {
+	Settings string `json:"settings"`
+	Outcomes []model.Outcome `json:"outcomes"`
+	Decision model.BetDecision `json:"decision"`
+	Outcome string `json:"outcome,omitempty"`
+	Skip bool `json:"skip"`
+	Filter string `json:"filter,omitempty"`
+	ComparedValue float64 `json:"compared_value"`
+	Stealth simulateStealth `json:"stealth"`
+	WouldBet bool `json:"would_bet"`
+}
+
+// simulateStealth reports how stealth mode changed the stake.
+type simulateStealth struct {
+	Enabled bool `json:"enabled"`
+	Applied bool `json:"applied"`
+	AmountBefore int `json:"amount_before"`
+	Reduction int `json:"reduction"`
+}
+
+// handleBetSimulate runs a bet calculation for the given outcomes, balance
+// and bet settings without placing anything.
+func (s *AnalyticsServer) handleBetSimulate(w http.ResponseWriter, r *http.Request) {
+	var req simulateRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxSimulateBodyBytes)).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body: " + err.Error()})
+		return
+	}
+
+	settings, err := parseSimulateSettings(req.Settings)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+		return
+	}
+
+	if len(req.Outcomes) < 2 || len(req.Outcomes) > maxSimulateOutcomes {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: fmt.Sprintf("between 2 and %d outcomes are required", maxSimulateOutcomes),
+		})
+		return
+	}
+	if req.Balance < 0 {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "balance must not be negative"})
+		return
+	}
+	for i := range req.Outcomes {
+		o := &req.Outcomes[i]
+		if o.TotalUsers < 0 || o.TotalPoints < 0 || o.TopPoints < 0 {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: fmt.Sprintf("outcome %d has negative values", i+1)})
+			return
+		}
+		if o.ID == "" {
+			o.ID = strconv.Itoa(i + 1)
+		}
+		if o.Title == "" {
+			o.Title = "Outcome " + strconv.Itoa(i+1)
+		}
+	}
+
+	// The stake before stealth mode is computed with an identical bet that
+	// has stealth disabled; every other step of the calculation is the same.
+	plain := *settings
+	plain.StealthMode = false
+	before := simulateBet(req, &plain).Decision
+
+	bet := simulateBet(req, settings)
+	decision := bet.Decision
+	skip, rule, comparedValue := bet.CheckFilters()
+
+	resp := simulateResponse{
+		Settings:      settings.String(),
+		Outcomes:      bet.Outcomes,
+		Decision:      decision,
+		Skip:          skip,
+		ComparedValue: comparedValue,
+		Stealth: simulateStealth{
+			Enabled:      settings.StealthMode,
+			Applied:      decision.Amount != before.Amount,
+			AmountBefore: before.Amount,
+			Reduction:    before.Amount - decision.Amount,
+		},
+		WouldBet: !skip && decision.Choice >= 0 && !decision.BelowMinimum(),
+	}
+	if decision.Choice >= 0 && decision.Choice < len(bet.Outcomes) {
+		resp.Outcome = bet.Outcomes[decision.Choice].Title
+	}
+	if skip && rule != nil {
+		resp.Filter = rule.String()
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// simulateBet calculates a bet on a private copy of the request outcomes.
+func simulateBet(req simulateRequest, settings *model.BetSettings) *model.Bet {
+	outcomes := make([]model.Outcome, len(req.Outcomes))
+	copy(outcomes, req.Outcomes)
+
+	bet := model.NewBet(outcomes, settings)
+	bet.UpdateOutcomes(req.Outcomes)
+	bet.SecondsLeft = req.SecondsLeft
+	bet.Calculate(req.Balance)
+	return bet
+}
+
+// parseSimulateSettings decodes and validates the settings of a simulation
+// request on top of the default bet settings. JSON is valid YAML, so the
+// config structs are decoded with their YAML keys.
+func parseSimulateSettings(raw json.RawMessage) (*model.BetSettings, error) {
+	var bsc *config.BetSettingsConfig
+	if len(raw) > 0 && string(raw) != "null" {
+		bsc = &config.BetSettingsConfig{}
+		if err := yaml.Unmarshal(raw, bsc); err != nil {
+			return nil, fmt.Errorf("invalid settings: %w", err)
+		}
+	}
+
+	settings, err := config.ResolveBetSettings(bsc, model.DefaultBetSettings())
+	if err != nil {
+		return nil, fmt.Errorf("invalid settings: %w", err)
+	}
+	return settings, nil
+}
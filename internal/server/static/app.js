@@ -85,6 +85,20 @@
   }
 
   // ── Rendering ─────────────────────────────────────────────────────────
+  // Watch streak badge: the running streak, or the streak lost on the
+  // last missed broadcast.
+  function streakBadgeHTML(streak) {
+    if (!streak) return "";
+    var missed = streak.broadcasts_missed ? streak.broadcasts_missed + " broadcast(s) missed" : "no broadcasts missed";
+    if (streak.value > 0) {
+      return '<span class="badge streak" title="Watch streak, ' + missed + '">🔥 ' + streak.value + "</span>";
+    }
+    if (streak.broken_value > 0) {
+      return '<span class="badge streak-broken" title="Streak of ' + streak.broken_value + " lost, " + missed + '">🔥 0</span>';
+    }
+    return "";
+  }
+
   function renderStreamers(streamers) {
     var grid = document.getElementById("streamers-grid");
     if (!streamers || streamers.length === 0) {
@@ -103,12 +117,13 @@
         var statusClass = s.is_online ? "online" : "offline";
         var statusText = s.is_online ? "Online" : "Offline";
         var categoryBadge = s.is_category_watched ? '<span class="badge category">CAT</span>' : "";
+        var streakBadge = streakBadgeHTML(s.streak);
         var accountBadge = s.account ? '<span class="badge account">' + escapeHTML(s.account) + "</span>" : "";
         var gameText = s.game ? s.game : "";
         var viewersText = s.is_online ? s.viewers_count + " viewers" : "";
         var details = [gameText, viewersText].filter(Boolean).join(" · ");
 
-        return '<div class="streamer-card ' + statusClass + '">' + '  <div class="name"><a href="' + s.streamer_url + '" target="_blank">' + (s.display_name || s.username) + "</a>" + accountBadge + "</div>" + '  <div class="status">' + '    <span class="badge ' + statusClass + '">' + statusText + "</span>" + categoryBadge + "  </div>" + '  <div class="details">' + '    <span class="points">' + formatPoints(s.channel_points) + " pts</span>" + (details ? " · " + details : "") + (s.title ? "<br><em>" + escapeHTML(s.title) + "</em>" : "") + "  </div>" + "</div>";
+        return '<div class="streamer-card ' + statusClass + '">' + '  <div class="name"><a href="' + s.streamer_url + '" target="_blank">' + (s.display_name || s.username) + "</a>" + accountBadge + "</div>" + '  <div class="status">' + '    <span class="badge ' + statusClass + '">' + statusText + "</span>" + categoryBadge + streakBadge + "  </div>" + '  <div class="details">' + '    <span class="points">' + formatPoints(s.channel_points) + " pts</span>" + (details ? " · " + details : "") + (s.title ? "<br><em>" + escapeHTML(s.title) + "</em>" : "") + "  </div>" + "</div>";
       })
       .join("");
   }
@@ -141,11 +156,53 @@
       .join("");
   }
 
+  function formatMinutes(minutes) {
+    if (minutes < 60) return minutes + "m";
+    return Math.floor(minutes / 60) + "h " + (minutes % 60) + "m";
+  }
+
+  function renderDrops(accounts) {
+    var list = document.getElementById("drops-list");
+    var total = 0;
+    var html = accounts
+      .map(function (a) {
+        total += a.campaigns.length;
+        if (a.campaigns.length === 0) return "";
+        var campaigns = a.campaigns
+          .map(function (c) {
+            var source = c.streamer ? "Progressing on " + escapeHTML(c.streamer) : c.available_streamers.length > 0 ? "Available on " + escapeHTML(c.available_streamers.join(", ")) : "No streamer available";
+            var drops = c.drops
+              .map(function (d) {
+                var status;
+                if (d.claimed) status = '<span class="badge online">Claimed</span>';
+                else if (!d.achievable) status = '<span class="badge unachievable">Not achievable</span>';
+                else if (d.eta) status = "ETA " + new Date(d.eta).toLocaleString();
+                else status = formatMinutes(d.remaining_minutes) + " left";
+                return "<tr>" + "<td>" + escapeHTML(d.benefit || d.name) + "</td>" + '<td><div class="progress"><div class="progress-fill" style="width:' + d.progress + '%"></div></div></td>' + "<td>" + d.progress + "% (" + d.current_minutes_watched + "/" + d.minutes_required + "m)</td>" + "<td>" + status + "</td>" + "</tr>";
+              })
+              .join("");
+            return '<div class="campaign-card">' + '  <div class="name">' + escapeHTML(c.name) + '<span class="badge account">' + escapeHTML(a.account) + "</span></div>" + '  <div class="details">' + escapeHTML(c.game || "") + " · ends " + new Date(c.end_at).toLocaleString() + " · " + source + "</div>" + '  <table class="drops-table"><tbody>' + drops + "</tbody></table>" + "</div>";
+          })
+          .join("");
+        return campaigns;
+      })
+      .join("");
+
+    list.innerHTML = total === 0 ? '<div class="loading">No active drop campaigns.</div>' : html;
+  }
+
   // ── Data refresh ──────────────────────────────────────────────────────
+  var activeTab = "overview";
+
   async function refresh() {
     try {
       var filterQuery = buildFilterParams();
       var separator = filterQuery ? "?" + filterQuery : "";
+      if (activeTab === "drops") {
+        var account = document.getElementById("filter-account").value;
+        renderDrops(await fetchJSON("/api/drops" + (account ? "?account=" + encodeURIComponent(account) : "")));
+        return;
+      }
       var results = await Promise.all([fetchJSON("/api/streamers" + separator), fetchJSON("/api/stats" + separator)]);
       renderStreamers(results[0]);
       renderStats(results[1]);
@@ -154,6 +211,21 @@
     }
   }
 
+  function initTabs() {
+    document.querySelectorAll("#tabs .tab").forEach(function (button) {
+      button.addEventListener("click", function () {
+        activeTab = button.dataset.tab;
+        document.querySelectorAll("#tabs .tab").forEach(function (b) {
+          b.classList.toggle("active", b === button);
+        });
+        document.querySelectorAll(".tab-panel").forEach(function (panel) {
+          panel.classList.toggle("hidden", panel.id !== "tab-" + activeTab);
+        });
+        refresh();
+      });
+    });
+  }
+
   // ── Event listeners ───────────────────────────────────────────────────
   function initFilterListeners() {
     var selects = ["filter-account", "filter-category", "filter-online"];
@@ -174,8 +246,107 @@
     document.getElementById("clear-filters").addEventListener("click", clearFilters);
   }
 
+  // ── Bet simulator ─────────────────────────────────────────────────────
+  const MAX_SIM_OUTCOMES = 10;
+
+  function addSimOutcome(users, points, topPoints) {
+    var tbody = document.getElementById("sim-outcomes");
+    if (tbody.children.length >= MAX_SIM_OUTCOMES) return;
+
+    var row = document.createElement("tr");
+    row.innerHTML = '<td class="sim-outcome-index"></td>' + '<td><input type="number" min="0" class="sim-users" value="' + users + '"></td>' + '<td><input type="number" min="0" class="sim-points" value="' + points + '"></td>' + '<td><input type="number" min="0" class="sim-top-points" value="' + topPoints + '"></td>' + '<td><button type="button" class="sim-remove">✕</button></td>';
+    row.querySelector(".sim-remove").addEventListener("click", function () {
+      if (tbody.children.length <= 2) return;
+      row.remove();
+      numberSimOutcomes();
+    });
+    tbody.appendChild(row);
+    numberSimOutcomes();
+  }
+
+  function numberSimOutcomes() {
+    var rows = document.querySelectorAll("#sim-outcomes tr");
+    rows.forEach(function (row, i) {
+      row.querySelector(".sim-outcome-index").textContent = "#" + (i + 1);
+    });
+  }
+
+  function readSimOutcomes() {
+    var rows = document.querySelectorAll("#sim-outcomes tr");
+    return Array.prototype.map.call(rows, function (row) {
+      return {
+        total_users: parseInt(row.querySelector(".sim-users").value, 10) || 0,
+        total_points: parseInt(row.querySelector(".sim-points").value, 10) || 0,
+        top_points: parseInt(row.querySelector(".sim-top-points").value, 10) || 0,
+      };
+    });
+  }
+
+  function renderSimResult(data) {
+    var el = document.getElementById("sim-result");
+    if (data.error) {
+      el.innerHTML = '<div class="sim-error">' + escapeHTML(data.error) + "</div>";
+      return;
+    }
+
+    var verdict = data.would_bet ? '<span class="badge online">Bet</span>' : '<span class="badge offline">No bet</span>';
+    var lines = [
+      "Outcome: " + escapeHTML(data.outcome || "none"),
+      "Amount: " + formatPoints(data.decision.amount),
+      "Filters: " + (data.skip ? "skip (" + escapeHTML(data.filter || "") + ", value " + data.compared_value.toFixed(2) + ")" : "pass"),
+      "Stealth: " + (data.stealth.applied ? "reduced " + formatPoints(data.stealth.amount_before) + " by " + data.stealth.reduction : data.stealth.enabled ? "enabled, not needed" : "disabled"),
+    ];
+    var odds = data.outcomes
+      .map(function (o, i) {
+        return "#" + (i + 1) + " odds " + o.odds.toFixed(2) + " · " + o.percentage_users.toFixed(1) + "% users";
+      })
+      .join("<br>");
+
+    el.innerHTML = "<div>" + verdict + "</div><div>" + lines.join("<br>") + '</div><div class="sim-odds">' + odds + '</div><div class="sim-settings">' + escapeHTML(data.settings) + "</div>";
+  }
+
+  async function submitSimulation(e) {
+    e.preventDefault();
+
+    var settings;
+    try {
+      var raw = document.getElementById("sim-settings").value.trim();
+      settings = raw ? JSON.parse(raw) : null;
+    } catch (err) {
+      renderSimResult({ error: "Bet settings are not valid JSON: " + err.message });
+      return;
+    }
+
+    try {
+      var resp = await fetch("/api/bet/simulate", {
+        method: "POST",
+        headers: { "Content-Type": "application/json" },
+        body: JSON.stringify({
+          balance: parseInt(document.getElementById("sim-balance").value, 10) || 0,
+          seconds_left: parseFloat(document.getElementById("sim-seconds-left").value) || 0,
+          outcomes: readSimOutcomes(),
+          settings: settings,
+        }),
+      });
+      renderSimResult(await resp.json());
+    } catch (err) {
+      renderSimResult({ error: "Simulation failed: " + err.message });
+    }
+  }
+
+  function initSimulator() {
+    addSimOutcome(60, 40000, 5000);
+    addSimOutcome(40, 25000, 4000);
+    document.getElementById("sim-add-outcome").addEventListener("click", function () {
+      addSimOutcome(0, 0, 0);
+    });
+    document.getElementById("simulator-form").addEventListener("submit", submitSimulation);
+  }
+
   // ── Bootstrap ─────────────────────────────────────────────────────────
   initFilterListeners();
+  initTabs();
+  initSimulator();
   loadFilters();
   refresh();
 
@@ -8,6 +8,7 @@
   const EVENT_EMOJIS = {
     GAIN_FOR_WATCH: "📺",
     GAIN_FOR_WATCH_STREAK: "📺",
+    WATCH_STREAK_AT_RISK: "⏳",
     GAIN_FOR_CLAIM: "🎁",
     GAIN_FOR_RAID: "🎁",
     BONUS_CLAIM: "💰",
@@ -15,6 +16,8 @@
     BET_FILTERS: "🎰",
     BET_GENERAL: "🎰",
     BET_FAILED: "🎰",
+    BET_UNCONFIRMED: "⚠️",
+    BET_LOCKED: "🔒",
     BET_WIN: "🏆",
     BET_LOSE: "💸",
     BET_REFUND: "↩️",
@@ -22,9 +25,16 @@
     DROP_STATUS: "📦",
     STREAMER_ONLINE: "🟢",
     STREAMER_OFFLINE: "⚫",
+    SESSION_SUMMARY: "📋",
     JOIN_RAID: "⚔️",
+    RAID_SKIPPED: "🛡️",
     CHAT_MENTION: "💬",
     MOMENT_CLAIM: "🎉",
+    REWARD_REDEEM: "🛒",
+    GOAL_CONTRIBUTE: "🤝",
+    HYPE_TRAIN_START: "🚂",
+    HYPE_TRAIN_LEVEL: "🚂",
+    POLL_START: "📊",
   };
 
   // Category display config
@@ -40,11 +50,11 @@
   // Category to events mapping (must match backend)
   const CATEGORY_EVENTS = {
     drops: ["DROP_CLAIM", "DROP_STATUS"],
-    points: ["GAIN_FOR_WATCH", "GAIN_FOR_WATCH_STREAK", "GAIN_FOR_CLAIM", "GAIN_FOR_RAID", "BONUS_CLAIM"],
-    bets: ["BET_START", "BET_WIN", "BET_LOSE", "BET_REFUND", "BET_FILTERS", "BET_GENERAL", "BET_FAILED"],
-    raids: ["JOIN_RAID"],
-    streams: ["STREAMER_ONLINE", "STREAMER_OFFLINE"],
-    other: ["MOMENT_CLAIM", "CHAT_MENTION"],
+    points: ["GAIN_FOR_WATCH", "GAIN_FOR_WATCH_STREAK", "WATCH_STREAK_AT_RISK", "GAIN_FOR_CLAIM", "GAIN_FOR_RAID", "BONUS_CLAIM", "REWARD_REDEEM", "GOAL_CONTRIBUTE"],
+    bets: ["BET_START", "BET_WIN", "BET_LOSE", "BET_REFUND", "BET_FILTERS", "BET_GENERAL", "BET_FAILED", "BET_UNCONFIRMED", "BET_LOCKED"],
+    raids: ["JOIN_RAID", "RAID_SKIPPED"],
+    streams: ["STREAMER_ONLINE", "STREAMER_OFFLINE", "SESSION_SUMMARY"],
+    other: ["MOMENT_CLAIM", "CHAT_MENTION", "HYPE_TRAIN_START", "HYPE_TRAIN_LEVEL", "POLL_START"],
   };
 
   // ── Utility functions ────────────────────────────────────────────────
@@ -0,0 +1,34 @@
+// Package streaks persists the watch streak state of an account's streamers
+// so streak counts and misses survive restarts.
+package streaks
+
+import (
+	"fmt"
+
+	"github.com/Guliveer/twitch-miner-go/internal/jsonutil"
+	"github.com/Guliveer/twitch-miner-go/internal/model"
+)
+
+// Path returns the streak file path for an account, under the streaks
+// directory of DATA_DIR.
+func Path(username string) string {
+	return jsonutil.DataPath("streaks", username)
+}
+
+// Load reads the streak states stored at path, keyed by lowercase streamer
+// login. A missing file returns an error wrapping os.ErrNotExist.
+func Load(path string) (map[string]model.StreakState, error) {
+	var states map[string]model.StreakState
+	if err := jsonutil.LoadFile(path, &states); err != nil {
+		return nil, fmt.Errorf("loading streaks: %w", err)
+	}
+	return states, nil
+}
+
+// Save writes the streak states to path atomically.
+func Save(path string, states map[string]model.StreakState) error {
+	if err := jsonutil.SaveFile(path, states); err != nil {
+		return fmt.Errorf("saving streaks: %w", err)
+	}
+	return nil
+}
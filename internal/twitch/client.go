@@ -82,19 +82,32 @@ type Client struct {
 	Log *logger.Logger
 	cfg *config.AccountConfig
 	spadeURLs *spadeCache
+	dropsFilter model.DropsFilter
+
+	// filteredCampaigns remembers why each campaign was filtered on the last
+	// sync, so that only newly filtered campaigns are logged at INFO.
+	filteredCampaignsMu sync.Mutex
+	filteredCampaigns map[string]string
+
+	// campaigns holds the result of the last SyncCampaigns.
+	campaignsMu sync.RWMutex
+	campaigns []*model.Campaign
 }
 
 // NewClient creates a new high-level Twitch Client from account configuration.
 func NewClient(cfg *config.AccountConfig, log *logger.Logger) (*Client, error) {
 	authenticator := auth.NewAuthenticator(cfg, log)
 	gqlClient := gql.NewClient(authenticator, log)
+	dropsFilter, _ := cfg.Drops.ToDropsFilter() // invalid patterns are rejected by Validate
 
 	return &Client{
-		Auth:      authenticator,
-		GQL:       gqlClient,
-		Log:       log,
-		cfg:       cfg,
-		spadeURLs: &spadeCache{entries: make(map[string]spadeCacheEntry)},
+		Auth:              authenticator,
+		GQL:               gqlClient,
+		Log:               log,
+		cfg:               cfg,
+		spadeURLs:         &spadeCache{entries: make(map[string]spadeCacheEntry)},
+		dropsFilter:       dropsFilter,
+		filteredCampaigns: make(map[string]string),
 	}, nil
 }
 
@@ -172,7 +185,7 @@ func (c *Client) updateStream(ctx context.Context, streamer *model.Streamer) err
 		return nil
 	}
 
-	info, err := c.GQL.GetStreamInfo(ctx, username)
+	info, err := shared.streamInfo(ctx, username, c.GQL.GetStreamInfo)
 	if err != nil {
 		return fmt.Errorf("getting stream info for %s: %w", username, err)
 	}
@@ -192,9 +205,11 @@ func (c *Client) updateStream(ctx context.Context, streamer *model.Streamer) err
 		info.ViewersCount,
 		constants.DropID,
 	)
+	streamer.TrackSession(time.Now())
 
 	// Resolve game slug if the API didn't return one (e.g. VideoPlayerStreamInfo
-	// persisted query omits slug). Check the registry first, then fetch via GQL.
+	// persisted query omits slug). Check the registry first, then fetch via the
+	// shared cache so accounts watching the same game only resolve it once.
 	if streamer.Stream.Game != nil && streamer.Stream.Game.Slug == "" && streamer.Stream.Game.ID != "" {
 		if slug := model.LookupGameSlug(streamer.Stream.Game.ID); slug != "" {
 			streamer.Stream.Game.Slug = slug
@@ -202,11 +217,10 @@ func (c *Client) updateStream(ctx context.Context, streamer *model.Streamer) err
 			gameID := streamer.Stream.Game.ID
 			// Release lock while making the network call to avoid blocking other goroutines.
 			streamer.Mu.Unlock()
-			slug, err := c.GQL.GetGameSlug(ctx, gameID)
+			slug, err := shared.gameSlug(ctx, gameID, c.GQL.GetGameSlug)
 			streamer.Mu.Lock()
 			if err == nil && slug != "" {
 				streamer.Stream.Game.Slug = slug
-				model.RegisterGameSlug(gameID, slug)
 			} else if err != nil {
 				c.Log.Debug("Failed to fetch game slug",
 					"streamer", username,
@@ -319,7 +333,8 @@ func (c *Client) updateSpadeURL(ctx context.Context, streamer *model.Streamer) e
 }
 
 // LoadChannelPointsContext loads channel points balance, multipliers,
-// available claims, and community goals for a streamer.
+// available claims, community goals and custom rewards for a streamer,
+// then applies its reward redemption rules.
 func (c *Client) LoadChannelPointsContext(ctx context.Context, streamer *model.Streamer) error {
 	streamer.Mu.RLock()
 	username := streamer.Username
@@ -334,6 +349,7 @@ func (c *Client) LoadChannelPointsContext(ctx context.Context, streamer *model.S
 	streamer.Mu.Lock()
 	streamer.ChannelPoints = cpc.Balance
 	streamer.ActiveMultipliers = cpc.ActiveMultipliers
+	streamer.CustomRewards = cpc.CustomRewards
 
 	goalsEnabled := streamer.Settings != nil && streamer.Settings.CommunityGoalsEnabled
 	if goalsEnabled {
@@ -360,36 +376,30 @@ func (c *Client) LoadChannelPointsContext(ctx context.Context, streamer *model.S
 		c.contributeToCommunityGoals(ctx, streamer)
 	}
 
+	c.redeemRewards(ctx, streamer)
+
 	return nil
 }
 
-// contributeToCommunityGoals contributes channel points to active community goals.
+// contributeToCommunityGoals contributes channel points to active community
+// goals within the limits of the streamer's goal policy.
 func (c *Client) contributeToCommunityGoals(ctx context.Context, streamer *model.Streamer) {
 	streamer.Mu.RLock()
-	hasActiveGoals := false
-	type goalSnapshot struct {
-		goalID                       string
-		title                        string
-		amountLeft                   int
-		perStreamUserMaxContribution int
-	}
-	var activeGoals []goalSnapshot
+	var activeGoals []model.CommunityGoal
 	for _, goal := range streamer.CommunityGoals {
 		if goal.Status == "STARTED" && goal.IsInStock {
-			hasActiveGoals = true
-			activeGoals = append(activeGoals, goalSnapshot{
-				goalID:                       goal.GoalID,
-				title:                        goal.Title,
-				amountLeft:                   goal.AmountLeft(),
-				perStreamUserMaxContribution: goal.PerStreamUserMaxContribution,
-			})
+			activeGoals = append(activeGoals, *goal)
 		}
 	}
+	var policy *model.GoalPolicy
+	if streamer.Settings != nil {
+		policy = streamer.Settings.GoalPolicy
+	}
 	username := streamer.Username
 	channelID := streamer.ChannelID
 	streamer.Mu.RUnlock()
 
-	if !hasActiveGoals {
+	if len(activeGoals) == 0 {
 		return
 	}
 
@@ -400,44 +410,60 @@ func (c *Client) contributeToCommunityGoals(ctx context.Context, streamer *model
 		return
 	}
 
-	goalMap := make(map[string]goalSnapshot, len(activeGoals))
-	for _, g := range activeGoals {
-		goalMap[g.goalID] = g
+	goalMap := make(map[string]*model.CommunityGoal, len(activeGoals))
+	for i := range activeGoals {
+		goalMap[activeGoals[i].GoalID] = &activeGoals[i]
+	}
+
+	streamContributed := 0
+	for _, contrib := range contributions {
+		streamContributed += contrib.UserPointsContributedThisStream
 	}
 
 	for _, contrib := range contributions {
-		goalID := contrib.Goal.ID
-		gs, ok := goalMap[goalID]
+		goal, ok := goalMap[contrib.Goal.ID]
 		if !ok {
 			continue
 		}
 
 		streamer.Mu.RLock()
 		balance := streamer.ChannelPoints
+		goalContributed := streamer.GoalContributions[goal.GoalID]
 		streamer.Mu.RUnlock()
 
-		userLeftToContribute := gs.perStreamUserMaxContribution - contrib.UserPointsContributedThisStream
-		amount := min(gs.amountLeft, userLeftToContribute, balance)
-
-		if amount > 0 {
-			transactionID := auth.GenerateHex(16)
-
-			err := c.GQL.ContributeToCommunityGoal(ctx, goalID, channelID, amount, transactionID)
-			if err != nil {
-				c.Log.Warn("Failed to contribute to community goal",
-					"streamer", username,
-					"goal", gs.title,
-					"error", err)
-			} else {
-				c.Log.Info("Contributed to community goal",
+		userLeftToContribute := goal.PerStreamUserMaxContribution - contrib.UserPointsContributedThisStream
+		amount, reason := policy.Contribution(goal, balance, userLeftToContribute, streamContributed, goalContributed)
+		if amount <= 0 {
+			if reason != "" {
+				c.Log.Debug("Skipping community goal",
 					"streamer", username,
-					"goal", gs.title,
-					"amount", amount)
-				streamer.Mu.Lock()
-				streamer.ChannelPoints -= amount
-				streamer.Mu.Unlock()
+					"goal", goal.Title,
+					"reason", reason)
 			}
+			continue
+		}
+
+		transactionID := auth.GenerateHex(16)
+		if err := c.GQL.ContributeToCommunityGoal(ctx, goal.GoalID, channelID, amount, transactionID); err != nil {
+			c.Log.Warn("Failed to contribute to community goal",
+				"streamer", username,
+				"goal", goal.Title,
+				"error", err)
+			continue
 		}
+
+		streamContributed += amount
+		streamer.Mu.Lock()
+		streamer.ChannelPoints -= amount
+		streamer.RecordGoalContribution(goal.GoalID, amount)
+		balance = streamer.ChannelPoints
+		streamer.Mu.Unlock()
+
+		c.Log.Event(ctx, model.EventGoalContribute, "Contributed to community goal",
+			"streamer", username,
+			"goal", goal.Title,
+			"amount", amount,
+			"balance", balance)
 	}
 }
 
@@ -468,8 +494,22 @@ func (c *Client) ClaimMoment(ctx context.Context, momentID string) error {
 }
 
 // GetChannelID fetches the channel ID for a streamer username.
+// Results are shared across all accounts in the process.
 func (c *Client) GetChannelID(ctx context.Context, username string) (string, error) {
-	return c.GQL.GetUserID(ctx, username)
+	return shared.channelID(ctx, username, c.GQL.GetUserID)
+}
+
+// InvalidateStreamInfo drops the process-wide cached stream info for a
+// channel so the next online check fetches fresh data. Call it when a PubSub
+// event reports a live status change.
+func (c *Client) InvalidateStreamInfo(username string) {
+	shared.invalidateStream(username)
+}
+
+// GetStreamInfo returns the stream info of any channel, tracked or not, from
+// the process-wide cache. A nil result means the channel is offline.
+func (c *Client) GetStreamInfo(ctx context.Context, username string) (*gql.StreamInfoResponse, error) {
+	return shared.streamInfo(ctx, username, c.GQL.GetStreamInfo)
 }
 
 // GetFollowers fetches the list of followed channel logins.
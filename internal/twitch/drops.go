@@ -23,6 +23,7 @@ func (c *Client) SyncCampaigns(ctx context.Context, streamers []*model.Streamer)
 	}
 
 	if len(dashboardCampaigns) == 0 {
+		c.setCampaigns(nil)
 		return nil
 	}
 
@@ -64,13 +65,16 @@ func (c *Client) SyncCampaigns(ctx context.Context, streamers []*model.Streamer)
 		c.Log.Warn("Failed to sync campaigns with inventory", "error", err)
 	}
 
+	campaigns = c.filterCampaigns(campaigns)
+	c.setCampaigns(campaigns)
+
 	for _, streamer := range streamers {
 		streamer.Mu.Lock()
 		if streamer.DropsCondition() {
 			var matchingCampaigns []model.Campaign
 			for _, campaign := range campaigns {
 				if len(campaign.Drops) > 0 && campaignMatchesStreamer(campaign, streamer) {
-					matchingCampaigns = append(matchingCampaigns, *campaign)
+					matchingCampaigns = append(matchingCampaigns, *campaign.Clone())
 				}
 			}
 			streamer.Stream.Campaigns = matchingCampaigns
@@ -81,6 +85,94 @@ func (c *Client) SyncCampaigns(ctx context.Context, streamers []*model.Streamer)
 	return nil
 }
 
+// Campaigns returns copies of the active drop campaigns kept by the last
+// SyncCampaigns, with their inventory and real-time progress.
+func (c *Client) Campaigns() []*model.Campaign {
+	c.campaignsMu.RLock()
+	defer c.campaignsMu.RUnlock()
+	result := make([]*model.Campaign, len(c.campaigns))
+	for i, campaign := range c.campaigns {
+		result[i] = campaign.Clone()
+	}
+	return result
+}
+
+// UpdateDropProgress applies real-time progress to a drop of the synced
+// campaigns. Returns a copy of the updated drop, or false if it is unknown.
+func (c *Client) UpdateDropProgress(dropID string, currentMinutesWatched, minutesRequired int) (model.Drop, bool) {
+	c.campaignsMu.Lock()
+	defer c.campaignsMu.Unlock()
+	for _, campaign := range c.campaigns {
+		if drop := campaign.FindDrop(dropID); drop != nil {
+			drop.SetProgress(currentMinutesWatched, minutesRequired)
+			return *drop, true
+		}
+	}
+	return model.Drop{}, false
+}
+
+// MarkDropClaimed flags a drop of the synced campaigns as claimed. Returns a
+// copy of the drop, or false if it is unknown.
+func (c *Client) MarkDropClaimed(dropID string) (model.Drop, bool) {
+	c.campaignsMu.Lock()
+	defer c.campaignsMu.Unlock()
+	for _, campaign := range c.campaigns {
+		if drop, ok := campaign.MarkDropClaimed(dropID); ok {
+			return drop, true
+		}
+	}
+	return model.Drop{}, false
+}
+
+func (c *Client) setCampaigns(campaigns []*model.Campaign) {
+	c.campaignsMu.Lock()
+	c.campaigns = campaigns
+	c.campaignsMu.Unlock()
+}
+
+// filterCampaigns applies the account's drops filter and logs the campaigns
+// it removes. A campaign is logged at INFO the first time it is filtered
+// for a given reason and at DEBUG on later syncs.
+func (c *Client) filterCampaigns(campaigns []*model.Campaign) []*model.Campaign {
+	if c.dropsFilter.IsEmpty() {
+		return campaigns
+	}
+
+	kept := make([]*model.Campaign, 0, len(campaigns))
+	filtered := make(map[string]string)
+	for _, campaign := range campaigns {
+		reason := c.dropsFilter.Apply(campaign)
+		if reason == "" {
+			kept = append(kept, campaign)
+			continue
+		}
+		filtered[campaign.ID] = reason
+
+		game := ""
+		if campaign.Game != nil {
+			game = campaign.Game.DisplayName
+		}
+
+		c.filteredCampaignsMu.Lock()
+		previous, seen := c.filteredCampaigns[campaign.ID]
+		c.filteredCampaignsMu.Unlock()
+
+		if seen && previous == reason {
+			c.Log.Debug("Drop campaign filtered",
+				"campaign", campaign.Name, "game", game, "reason", reason)
+		} else {
+			c.Log.Info("Drop campaign filtered",
+				"campaign", campaign.Name, "game", game, "reason", reason)
+		}
+	}
+
+	c.filteredCampaignsMu.Lock()
+	c.filteredCampaigns = filtered
+	c.filteredCampaignsMu.Unlock()
+
+	return kept
+}
+
 func (c *Client) syncCampaignsWithInventory(ctx context.Context, campaigns []*model.Campaign) ([]*model.Campaign, error) {
 	inventoryData, err := c.GQL.GetDropsInventory(ctx)
 	if err != nil {
@@ -239,7 +331,8 @@ func parseCampaign(raw json.RawMessage) (*model.Campaign, error) {
 		} `json:"game"`
 		Allow *struct {
 			Channels []struct {
-				ID string `json:"id"`
+				ID   string `json:"id"`
+				Name string `json:"name"`
 			} `json:"channels"`
 		} `json:"allow"`
 		TimeBasedDrops []struct {
@@ -273,14 +366,16 @@ func parseCampaign(raw json.RawMessage) (*model.Campaign, error) {
 	startAt, _ := time.Parse(time.RFC3339, data.StartAt)
 	endAt, _ := time.Parse(time.RFC3339, data.EndAt)
 
-	var channels []string
+	var channels, channelLogins []string
 	if data.Allow != nil {
 		for _, channel := range data.Allow.Channels {
 			channels = append(channels, channel.ID)
+			channelLogins = append(channelLogins, channel.Name)
 		}
 	}
 
 	campaign := model.NewCampaign(data.ID, data.Name, data.Status, gameInfo, startAt, endAt, channels)
+	campaign.ChannelLogins = channelLogins
 
 	for _, timeDrop := range data.TimeBasedDrops {
 		dropStart, _ := time.Parse(time.RFC3339, timeDrop.StartAt)
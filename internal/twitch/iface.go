@@ -20,8 +20,14 @@ type API interface {
 	JoinRaid(ctx context.Context, raidID string) error
 	ClaimMoment(ctx context.Context, momentID string) error
 	SyncCampaigns(ctx context.Context, streamers []*model.Streamer) error
+	Campaigns() []*model.Campaign
+	UpdateDropProgress(dropID string, currentMinutesWatched, minutesRequired int) (model.Drop, bool)
+	MarkDropClaimed(dropID string) (model.Drop, bool)
+	ClaimDrop(ctx context.Context, dropInstanceID string) error
 	ClaimAllDropsFromInventory(ctx context.Context) error
 	GetChannelID(ctx context.Context, username string) (string, error)
+	InvalidateStreamInfo(username string)
+	GetStreamInfo(ctx context.Context, username string) (*gql.StreamInfoResponse, error)
 	GetFollowers(ctx context.Context, limit int, order string) ([]string, error)
 	CheckViewerIsMod(ctx context.Context, streamer *model.Streamer)
 	RefreshSpadeURL(ctx context.Context, s *model.Streamer) error // re-fetch spade URL on demand
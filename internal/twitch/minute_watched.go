@@ -181,7 +181,10 @@ func (c *Client) sendMinuteWatchedForStreamer(ctx context.Context, httpClient *h
 
 	if spadeResp.StatusCode == http.StatusNoContent || spadeResp.StatusCode == http.StatusOK {
 		streamer.Mu.Lock()
-		streamer.Stream.UpdateMinuteWatched()
+		elapsed := streamer.Stream.UpdateMinuteWatched()
+		if session := streamer.CurrentSession(); session != nil {
+			session.MinutesWatched += elapsed
+		}
 		streamer.Mu.Unlock()
 
 		c.Log.Debug("Sent minute watched event",
@@ -2,74 +2,188 @@ package twitch
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
+	"time"
 
 	"github.com/Guliveer/twitch-miner-go/internal/auth"
+	"github.com/Guliveer/twitch-miner-go/internal/constants"
+	"github.com/Guliveer/twitch-miner-go/internal/gql"
 	"github.com/Guliveer/twitch-miner-go/internal/model"
 	"github.com/Guliveer/twitch-miner-go/internal/utils"
 )
 
+// ErrBetMayHaveLanded is wrapped by the MakePrediction error when an attempt
+// timed out, so the bet may have been placed even though no attempt
+// succeeded.
+var ErrBetMayHaveLanded = errors.New("bet may have been placed")
+
 // MakePrediction calculates and places a prediction bet for an event.
 // It delegates the bet calculation to model.EventPrediction and handles
 // the GQL request to place the bet. Uses event.Mu for thread-safe access.
+//
+// Transient failures (network errors, empty responses after a failed
+// integrity check, 5xx) are retried with backoff while the prediction
+// window is still open. The bet is calculated once against the latest
+// outcomes and balance, and every attempt resends that same bet with the
+// same transaction ID so Twitch can deduplicate one whose response was lost.
+// Rejections reported by Twitch are final. If an attempt timed out, the
+// returned error wraps ErrBetMayHaveLanded.
 func (c *Client) MakePrediction(ctx context.Context, streamer *model.Streamer, event *model.EventPrediction) error {
 	streamer.Mu.RLock()
-	balance := streamer.ChannelPoints
 	username := streamer.Username
 	streamer.Mu.RUnlock()
 
 	event.Mu.Lock()
-
-	decision := event.Bet.Calculate(balance)
-
 	title := event.Title
-	status := event.Status
 	eventID := event.EventID
+	locksAt := event.LocksAt
+	event.Mu.Unlock()
 
 	c.Log.Info("Completing bet",
 		"streamer", username,
 		"title", title,
 		"event", string(model.EventBetGeneral))
 
+	decision, chosenOutcome, proceed, err := c.prepareBet(ctx, streamer, event)
+	if !proceed {
+		return err
+	}
+
+	transactionID := auth.GenerateHex(16)
+	delay := constants.PredictionRetryBaseDelay
+	timedOut := false
+
+	for attempt := 1; ; attempt++ {
+		c.Log.Info("Placing bet",
+			"streamer", username,
+			"amount", utils.Millify(decision.Amount, 2),
+			"outcome", chosenOutcome,
+			"attempt", attempt,
+			"event", string(model.EventBetGeneral))
+
+		err = c.GQL.MakePrediction(ctx, eventID, decision.OutcomeID, decision.Amount, transactionID)
+		if err == nil {
+			broadcastID := ""
+			streamer.Mu.Lock()
+			if session := streamer.CurrentSession(); session != nil {
+				session.BetsPlaced++
+				broadcastID = session.BroadcastID
+			}
+			streamer.Mu.Unlock()
+
+			event.Mu.Lock()
+			event.BetPlaced = true
+			event.BroadcastID = broadcastID
+			event.Mu.Unlock()
+
+			c.Log.Info("Prediction placed successfully",
+				"streamer", username,
+				"event_id", eventID,
+				"outcome", chosenOutcome,
+				"amount", decision.Amount)
+			return nil
+		}
+
+		timedOut = timedOut || isTimeout(err)
+
+		retryable := !errors.Is(err, gql.ErrPredictionRejected) &&
+			!errors.Is(err, gql.ErrCircuitOpen) &&
+			ctx.Err() == nil
+		if retryable && !locksAt.IsZero() && time.Until(locksAt) > delay+constants.PredictionLockMargin {
+			c.Log.Warn("Failed to place bet, retrying",
+				"streamer", username,
+				"attempt", attempt,
+				"retry_in", delay.String(),
+				"error", err)
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(delay):
+			}
+			delay = min(delay*2, constants.PredictionRetryMaxDelay)
+
+			event.Mu.Lock()
+			status := event.Status
+			event.Mu.Unlock()
+			if status == "ACTIVE" {
+				continue
+			}
+			err = fmt.Errorf("event %s is not active anymore (status: %s): %w", eventID, status, err)
+		}
+
+		c.Log.Error("Failed to place bet",
+			"streamer", username,
+			"attempt", attempt,
+			"error", err,
+			"event", string(model.EventBetFailed))
+		if timedOut {
+			return fmt.Errorf("placing prediction: %w: %w", ErrBetMayHaveLanded, err)
+		}
+		return fmt.Errorf("placing prediction: %w", err)
+	}
+}
+
+// isTimeout reports whether a placement attempt timed out after the request
+// may already have reached Twitch.
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout())
+}
+
+// prepareBet calculates the bet to place. It returns
+// proceed=false when no bet should be placed, along with the error to
+// report (nil when the bet was deliberately skipped).
+func (c *Client) prepareBet(ctx context.Context, streamer *model.Streamer, event *model.EventPrediction) (model.BetDecision, string, bool, error) {
+	streamer.Mu.RLock()
+	balance := streamer.ChannelPoints
+	username := streamer.Username
+	streamer.Mu.RUnlock()
+
+	event.Mu.Lock()
+
+	if !event.LocksAt.IsZero() {
+		event.Bet.SecondsLeft = time.Until(event.LocksAt).Seconds()
+	}
+	decision := event.Bet.Calculate(balance)
+
+	title := event.Title
+	status := event.Status
+	eventID := event.EventID
+
 	if status != "ACTIVE" {
 		event.Mu.Unlock()
 		c.Log.Info("Event is not active anymore",
 			"streamer", username,
 			"status", status,
 			"event", string(model.EventBetFailed))
-		return fmt.Errorf("event %s is not active (status: %s)", eventID, status)
+		return decision, "", false, fmt.Errorf("event %s is not active (status: %s)", eventID, status)
 	}
 
-	skip, comparedValue := event.Bet.Skip()
-	var filterCondStr string
-	if event.Bet.Settings.FilterCondition != nil {
-		filterCondStr = event.Bet.Settings.FilterCondition.String()
-	}
+	skip, rule, comparedValue := event.Bet.CheckFilters()
+	filterMatch := event.Bet.Settings.FilterMatch
 
 	if skip {
 		event.Mu.Unlock()
-		c.Log.Info("Skip betting for event",
+		c.Log.Event(ctx, model.EventBetFilters, "Skip betting for event",
 			"streamer", username,
 			"title", title,
-			"event", string(model.EventBetFilters))
-		if filterCondStr != "" {
-			c.Log.Info("Skip settings applied",
-				"streamer", username,
-				"filter", filterCondStr,
-				"current_value", fmt.Sprintf("%.2f", comparedValue),
-				"event", string(model.EventBetFilters))
-		}
-		return nil
+			"match", filterMatch.String(),
+			"filter", rule.String(),
+			"current_value", fmt.Sprintf("%.2f", comparedValue))
+		return decision, "", false, nil
 	}
 
-	if decision.Amount < 10 {
+	if decision.BelowMinimum() {
 		event.Mu.Unlock()
 		c.Log.Info("Bet amount below minimum",
 			"streamer", username,
 			"amount", utils.Millify(decision.Amount, 2),
-			"minimum", 10,
+			"minimum", constants.MinBetAmount,
 			"event", string(model.EventBetGeneral))
-		return nil
+		return decision, "", false, nil
 	}
 
 	chosenOutcome := "unknown"
@@ -78,33 +192,5 @@ func (c *Client) MakePrediction(ctx context.Context, streamer *model.Streamer, e
 	}
 
 	event.Mu.Unlock()
-
-	c.Log.Info("Placing bet",
-		"streamer", username,
-		"amount", utils.Millify(decision.Amount, 2),
-		"outcome", chosenOutcome,
-		"event", string(model.EventBetGeneral))
-
-	transactionID := auth.GenerateHex(16)
-
-	err := c.GQL.MakePrediction(ctx, eventID, decision.OutcomeID, decision.Amount, transactionID)
-	if err != nil {
-		c.Log.Error("Failed to place bet",
-			"streamer", username,
-			"error", err,
-			"event", string(model.EventBetFailed))
-		return fmt.Errorf("placing prediction: %w", err)
-	}
-
-	event.Mu.Lock()
-	event.BetPlaced = true
-	event.Mu.Unlock()
-
-	c.Log.Info("Prediction placed successfully",
-		"streamer", username,
-		"event_id", eventID,
-		"outcome", chosenOutcome,
-		"amount", decision.Amount)
-
-	return nil
+	return decision, chosenOutcome, true, nil
 }
@@ -0,0 +1,106 @@
+package twitch
+
+import (
+	"context"
+	"time"
+
+	"github.com/Guliveer/twitch-miner-go/internal/auth"
+	"github.com/Guliveer/twitch-miner-go/internal/constants"
+	"github.com/Guliveer/twitch-miner-go/internal/model"
+)
+
+// redeemRewards redeems the streamer's custom rewards matched by its redeem
+// rules. Each reward is redeemed at most once per call, by the first rule
+// that matches it. Dry-run rules log the redemption without making it.
+// A failed redemption is retried on later calls with the same transaction
+// ID, up to RewardRedeemMaxFailures times per stream.
+func (c *Client) redeemRewards(ctx context.Context, streamer *model.Streamer) {
+	streamer.Mu.RLock()
+	if !streamer.IsOnline || streamer.Settings == nil || len(streamer.Settings.RedeemRules) == 0 {
+		streamer.Mu.RUnlock()
+		return
+	}
+	rules := streamer.Settings.RedeemRules
+	rewards := make([]model.CustomReward, len(streamer.CustomRewards))
+	copy(rewards, streamer.CustomRewards)
+	username := streamer.Username
+	channelID := streamer.ChannelID
+	streamer.Mu.RUnlock()
+
+	now := time.Now()
+	for i := range rewards {
+		reward := &rewards[i]
+		if !reward.IsRedeemable(now) {
+			continue
+		}
+
+		streamer.Mu.RLock()
+		balance := streamer.ChannelPoints
+		redeemed := streamer.RewardRedemptions[reward.ID]
+		pending := streamer.PendingRedemptions[reward.ID]
+		streamer.Mu.RUnlock()
+
+		var rule *model.RedeemRule
+		for j := range rules {
+			if rules[j].Match(reward, balance, redeemed) {
+				rule = &rules[j]
+				break
+			}
+		}
+		if rule == nil {
+			continue
+		}
+
+		if rule.DryRun {
+			c.Log.Event(ctx, model.EventRewardRedeem, "Would redeem reward (dry run)",
+				"streamer", username,
+				"reward", reward.Title,
+				"cost", reward.Cost,
+				"rule", rule.Name,
+				"balance", balance)
+			streamer.Mu.Lock()
+			streamer.RecordRedemption(reward.ID)
+			streamer.Mu.Unlock()
+			continue
+		}
+
+		if pending.Failures >= constants.RewardRedeemMaxFailures {
+			continue
+		}
+		transactionID := pending.TransactionID
+		if transactionID == "" {
+			transactionID = auth.GenerateHex(16)
+		}
+
+		if err := c.GQL.RedeemCustomReward(ctx, channelID, *reward, rule.Text, transactionID); err != nil {
+			streamer.Mu.Lock()
+			failures := streamer.RecordRedemptionFailure(reward.ID, transactionID)
+			streamer.Mu.Unlock()
+
+			msg := "Failed to redeem reward, retrying on the next refresh"
+			if failures >= constants.RewardRedeemMaxFailures {
+				msg = "Failed to redeem reward, giving up until the next stream"
+			}
+			c.Log.Warn(msg,
+				"streamer", username,
+				"reward", reward.Title,
+				"rule", rule.Name,
+				"attempt", failures,
+				"error", err)
+			continue
+		}
+
+		streamer.Mu.Lock()
+		streamer.ChannelPoints -= reward.Cost
+		streamer.RecordRedemption(reward.ID)
+		balance = streamer.ChannelPoints
+		streamer.Mu.Unlock()
+
+		c.Log.Event(ctx, model.EventRewardRedeem, "Redeemed reward",
+			"streamer", username,
+			"reward", reward.Title,
+			"cost", reward.Cost,
+			"rule", rule.Name,
+			"balance", balance)
+	}
+}
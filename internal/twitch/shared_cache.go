@@ -0,0 +1,218 @@
+package twitch
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/Guliveer/twitch-miner-go/internal/constants"
+	"github.com/Guliveer/twitch-miner-go/internal/gql"
+	"github.com/Guliveer/twitch-miner-go/internal/model"
+)
+
+// shared is the process-wide cache of public channel data consulted by every
+// Client. Accounts that follow the same channels resolve channel IDs, poll
+// stream info and look up game slugs through it, so each lookup hits the GQL
+// API once per TTL instead of once per account.
+var shared = newSharedCache()
+
+// sharedCache stores public, account-independent Twitch data with TTLs.
+// Concurrent misses for the same key are collapsed into a single GQL request
+// via singleflight. Game slugs never expire and are stored in the
+// model-level game slug registry so Stream.GameSlug() can read them.
+type sharedCache struct {
+	group singleflight.Group
+
+	channelIDs *ttlMap[string]
+	streams    *ttlMap[*gql.StreamInfoResponse]
+}
+
+func newSharedCache() *sharedCache {
+	return &sharedCache{
+		channelIDs: newTTLMap[string](),
+		streams:    newTTLMap[*gql.StreamInfoResponse](),
+	}
+}
+
+// channelID returns the cached channel ID for a login, or resolves it with
+// fetch. Errors are never cached.
+func (sc *sharedCache) channelID(ctx context.Context, login string, fetch func(context.Context, string) (string, error)) (string, error) {
+	key := strings.ToLower(login)
+	if id, ok := sc.channelIDs.get(key); ok {
+		return id, nil
+	}
+
+	v, err := sc.do(ctx, "channel_id:"+key, func(ctx context.Context) (any, error) {
+		if id, ok := sc.channelIDs.get(key); ok {
+			return id, nil
+		}
+		id, err := fetch(ctx, login)
+		if err != nil {
+			return "", err
+		}
+		if id != "" {
+			sc.channelIDs.set(key, id, constants.SharedChannelIDTTL)
+		}
+		return id, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return v.(string), nil
+}
+
+// streamInfo returns the cached stream info for a login, or fetches it.
+// A nil result means the channel is offline; offline results are cached for a
+// shorter TTL so a stream going live is noticed quickly. The returned value is
+// a private copy the caller may mutate.
+func (sc *sharedCache) streamInfo(ctx context.Context, login string, fetch func(context.Context, string) (*gql.StreamInfoResponse, error)) (*gql.StreamInfoResponse, error) {
+	key := strings.ToLower(login)
+	if info, ok := sc.streams.get(key); ok {
+		return copyStreamInfo(info), nil
+	}
+
+	v, err := sc.do(ctx, "stream:"+key, func(ctx context.Context) (any, error) {
+		if info, ok := sc.streams.get(key); ok {
+			return info, nil
+		}
+		info, err := fetch(ctx, login)
+		if err != nil {
+			return nil, err
+		}
+		ttl := constants.SharedStreamInfoTTL
+		if info == nil {
+			ttl = constants.SharedOfflineStatusTTL
+		}
+		sc.streams.set(key, info, ttl)
+		sc.streams.prune()
+		return info, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return copyStreamInfo(v.(*gql.StreamInfoResponse)), nil
+}
+
+// do runs fn once for all concurrent callers of key. fn gets a context that
+// is detached from the cancellation of any single caller and bounded by
+// SharedLookupTimeout, so one account shutting down does not fail the
+// lookups other accounts are waiting on. Each caller still stops waiting
+// when its own ctx is done.
+func (sc *sharedCache) do(ctx context.Context, key string, fn func(context.Context) (any, error)) (any, error) {
+	ch := sc.group.DoChan(key, func() (any, error) {
+		lookupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), constants.SharedLookupTimeout)
+		defer cancel()
+		return fn(lookupCtx)
+	})
+	select {
+	case result := <-ch:
+		return result.Val, result.Err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// invalidateStream drops the cached stream info for a login, e.g. after a
+// PubSub stream-up/stream-down event made the cached live status stale.
+func (sc *sharedCache) invalidateStream(login string) {
+	sc.streams.delete(strings.ToLower(login))
+}
+
+// gameSlug returns the slug for a game ID from the global registry, or
+// fetches and registers it.
+func (sc *sharedCache) gameSlug(ctx context.Context, gameID string, fetch func(context.Context, string) (string, error)) (string, error) {
+	if slug := model.LookupGameSlug(gameID); slug != "" {
+		return slug, nil
+	}
+
+	v, err := sc.do(ctx, "game_slug:"+gameID, func(ctx context.Context) (any, error) {
+		if slug := model.LookupGameSlug(gameID); slug != "" {
+			return slug, nil
+		}
+		slug, err := fetch(ctx, gameID)
+		if err != nil {
+			return "", err
+		}
+		model.RegisterGameSlug(gameID, slug)
+		return slug, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return v.(string), nil
+}
+
+// copyStreamInfo returns a deep copy of info so callers can mutate the game
+// and tags without racing with other accounts reading the cached value.
+func copyStreamInfo(info *gql.StreamInfoResponse) *gql.StreamInfoResponse {
+	if info == nil {
+		return nil
+	}
+	cp := *info
+	if info.Game != nil {
+		game := *info.Game
+		cp.Game = &game
+	}
+	if info.Tags != nil {
+		cp.Tags = make([]model.Tag, len(info.Tags))
+		copy(cp.Tags, info.Tags)
+	}
+	return &cp
+}
+
+// ttlMap is a small thread-safe map whose entries expire after a per-entry TTL.
+type ttlMap[T any] struct {
+	mu      sync.Mutex
+	entries map[string]ttlEntry[T]
+}
+
+type ttlEntry[T any] struct {
+	value     T
+	expiresAt time.Time
+}
+
+func newTTLMap[T any]() *ttlMap[T] {
+	return &ttlMap[T]{entries: make(map[string]ttlEntry[T])}
+}
+
+func (m *ttlMap[T]) get(key string) (T, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry, ok := m.entries[key]
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(m.entries, key)
+		var zero T
+		return zero, false
+	}
+	return entry.value, true
+}
+
+func (m *ttlMap[T]) set(key string, value T, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries[key] = ttlEntry[T]{value: value, expiresAt: time.Now().Add(ttl)}
+}
+
+func (m *ttlMap[T]) delete(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.entries, key)
+}
+
+func (m *ttlMap[T]) prune() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	for key, entry := range m.entries {
+		if now.After(entry.expiresAt) {
+			delete(m.entries, key)
+		}
+	}
+}
@@ -1,9 +1,14 @@
-// Package watcher provides the CategoryWatcher that automatically discovers
-// and tracks streamers based on configured game categories.
+// Package watcher provides the CategoryWatcher and DropsWatcher, which
+// automatically discover and track streamers based on configured game
+// categories and active drop campaigns.
 package watcher
 
 import (
+	"cmp"
 	"context"
+	"fmt"
+	"maps"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -16,9 +21,25 @@ import (
 )
 
 type categoryEntry struct {
-	Slug      string
-	GameID    string
-	DropsOnly *bool
+	Slug            string
+	GameID          string
+	DropsOnly       *bool
+	Slots           int
+	PreferLanguages []string
+	Filter          *model.CategoryFilter
+	Auto            bool // Added for an active drop campaign
+}
+
+// pickStats records how the category watcher's picks of a streamer turned out.
+type pickStats struct {
+	picks    int
+	failures int // Went offline or left the category within CategoryPickGracePeriod
+}
+
+// reliability returns the smoothed share of picks that did not fail. Streamers
+// never picked before score 0.5.
+func (ps pickStats) reliability() float64 {
+	return float64(ps.picks-ps.failures+1) / float64(ps.picks+2)
 }
 
 // CategoryWatcher polls Twitch GQL for top streams in configured categories
@@ -33,23 +54,38 @@ type CategoryWatcher struct {
 	pollInterval     time.Duration
 	blacklist        map[string]bool
 	streamerDefaults *model.StreamerSettings
+	autoDrops        bool
+	campaigns        func() []*model.Campaign
 
-	categoryStreamers map[string]string
+	categoryStreamers map[string][]string
+	pickedAt          map[string]time.Time
+	history           map[string]pickStats
 }
 
 // NewCategoryWatcher creates a new CategoryWatcher from configuration.
+// campaigns returns the active campaigns from the latest campaign sync and
+// is only used with auto_drops_categories.
 func NewCategoryWatcher(
 	cfg config.CategoryWatcherConfig,
 	gqlClient *gql.Client,
 	log *logger.Logger,
 	blacklist []string,
 	streamerDefaults *model.StreamerSettings,
+	campaigns func() []*model.Campaign,
 ) *CategoryWatcher {
 	categories := make([]categoryEntry, 0, len(cfg.Categories))
 	for _, categoryCfg := range cfg.Categories {
+		slots := categoryCfg.Slots
+		if slots <= 0 {
+			slots = 1
+		}
+		filter, _ := categoryCfg.ToCategoryFilter() // invalid patterns are rejected by Validate
 		categories = append(categories, categoryEntry{
-			Slug:      categoryCfg.Slug,
-			DropsOnly: categoryCfg.DropsOnly,
+			Slug:            categoryCfg.Slug,
+			DropsOnly:       categoryCfg.DropsOnly,
+			Slots:           slots,
+			PreferLanguages: categoryCfg.PreferLanguages,
+			Filter:          filter,
 		})
 	}
 
@@ -63,9 +99,9 @@ func NewCategoryWatcher(
 		interval = constants.DefaultCategoryWatcherInterval
 	}
 
-	catStreamers := make(map[string]string, len(categories))
+	catStreamers := make(map[string][]string, len(categories))
 	for _, cat := range categories {
-		catStreamers[cat.Slug] = ""
+		catStreamers[cat.Slug] = nil
 	}
 
 	return &CategoryWatcher{
@@ -76,21 +112,28 @@ func NewCategoryWatcher(
 		pollInterval:      interval,
 		blacklist:         blacklistMap,
 		streamerDefaults:  streamerDefaults,
+		autoDrops:         cfg.AutoDropsCategories,
+		campaigns:         campaigns,
 		categoryStreamers: catStreamers,
+		pickedAt:          make(map[string]time.Time),
+		history:           make(map[string]pickStats),
 	}
 }
 
 // Run starts the category watcher loop. It calls addStreamer when a new streamer
 // should be tracked and removeStreamer when a streamer should be removed.
-// The function blocks until the context is cancelled.
+// addStreamer reports whether it added the streamer; one it did not add was
+// already tracked by something else and is never owned or removed by the
+// watcher. The function blocks until the context is cancelled.
 func (cw *CategoryWatcher) Run(
 	ctx context.Context,
-	addStreamer func(context.Context, *model.Streamer),
+	addStreamer func(context.Context, *model.Streamer) bool,
 	removeStreamer func(string, string),
 	getTrackedStreamers func() []*model.Streamer,
 ) error {
 	cw.log.Info("👁️ CategoryWatcher started",
 		"categories", len(cw.categories),
+		"auto_drops_categories", cw.autoDrops,
 		"poll_interval", cw.pollInterval,
 	)
 
@@ -104,11 +147,11 @@ func (cw *CategoryWatcher) Run(
 		case <-ctx.Done():
 			cw.log.Info("👁️ CategoryWatcher stopping")
 			cw.mu.Lock()
-			for slug, username := range cw.categoryStreamers {
-				if username != "" {
+			for slug, usernames := range cw.categoryStreamers {
+				for _, username := range usernames {
 					removeStreamer(username, "category_watcher_shutdown")
-					cw.categoryStreamers[slug] = ""
 				}
+				cw.categoryStreamers[slug] = nil
 			}
 			cw.mu.Unlock()
 			return ctx.Err()
@@ -118,15 +161,18 @@ func (cw *CategoryWatcher) Run(
 	}
 }
 
-// evaluate checks all configured categories and adds/removes streamers as needed.
-// Streamers are only removed when they go offline or change category — NOT when
-// another streamer has more viewers. New streamers are only added when the slot
+// evaluate checks all configured categories and keeps their slots filled.
+// Streamers are only removed when they go offline, change category or their
+// slot is taken over by a regular streamer — NOT when another streamer has
+// more viewers. New streamers are only added to empty slots.
 func (cw *CategoryWatcher) evaluate(
 	ctx context.Context,
-	addStreamer func(context.Context, *model.Streamer),
+	addStreamer func(context.Context, *model.Streamer) bool,
 	removeStreamer func(string, string),
 	getTrackedStreamers func() []*model.Streamer,
 ) {
+	cw.syncDropsCategories(removeStreamer)
+
 	for i := range cw.categories {
 		cat := &cw.categories[i]
 
@@ -141,158 +187,342 @@ func (cw *CategoryWatcher) evaluate(
 
 		trackedStreamers := getTrackedStreamers()
 
-		if cw.isCategoryCovered(trackedStreamers, cat) {
-			cw.mu.Lock()
-			current := cw.categoryStreamers[cat.Slug]
-			if current != "" {
-				cw.log.Debug("Category now covered by regular streamer, removing category watcher",
-					"category", cat.Slug)
-				removeStreamer(current, "category_covered_by_regular")
-				cw.categoryStreamers[cat.Slug] = ""
-			}
-			cw.mu.Unlock()
-			continue
-		}
-
 		cw.mu.Lock()
-		currentUsername := cw.categoryStreamers[cat.Slug]
+		current := slices.Clone(cw.categoryStreamers[cat.Slug])
 		cw.mu.Unlock()
 
-		if currentUsername != "" {
-			valid, reason := cw.checkStreamerValidity(trackedStreamers, currentUsername, cat)
-			if valid {
-				continue // Still online and in the right category — keep them.
+		kept := make([]string, 0, len(current))
+		for _, username := range current {
+			valid, reason := cw.checkStreamerValidity(trackedStreamers, username, cat)
+			if !valid {
+				cw.release(cat.Slug, username, reason, removeStreamer)
+				continue
 			}
-			removeStreamer(currentUsername, reason)
-			cw.mu.Lock()
-			cw.categoryStreamers[cat.Slug] = ""
-			cw.mu.Unlock()
+			kept = append(kept, username) // Still online and in the right category — keep them.
 		}
 
-		streams, err := cw.gqlClient.GetTopStreamsByCategory(ctx, cat.Slug, 10, dropsOnly)
-		if err != nil {
-			cw.log.Warn("Failed to fetch top streams for category",
-				"category", cat.Slug,
-				"error", err,
-			)
+		// Regular streamers in the category take over slots, newest picks first.
+		wanted := max(cat.Slots-cw.countRegularStreamers(trackedStreamers, cat), 0)
+		for len(kept) > wanted {
+			username := kept[len(kept)-1]
+			kept = kept[:len(kept)-1]
+			cw.log.Debug("Category now covered by regular streamer, removing category watcher",
+				"category", cat.Slug, "streamer", username)
+			cw.release(cat.Slug, username, "category_covered_by_regular", removeStreamer)
+		}
+
+		free := wanted - len(kept)
+		if free == 0 {
 			continue
 		}
 
-		if len(streams) == 0 {
-			filterNote := ""
-			if dropsOnly {
-				filterNote = " (drops-only filter active)"
-			}
-			cw.log.Info("No live streams for category"+filterNote,
-				"category", cat.Slug,
-			)
+		candidates := cw.findCandidates(ctx, cat, dropsOnly, trackedStreamers)
+		if len(candidates) == 0 {
 			continue
 		}
 
-		if cat.GameID == "" && streams[0].GameID != "" {
-			cat.GameID = streams[0].GameID
-			cw.log.Info("Resolved category to game ID",
+		for _, candidate := range candidates[:min(free, len(candidates))] {
+			streamer := cw.newStreamer(ctx, cat, candidate)
+			if !addStreamer(ctx, streamer) {
+				// Tracked by someone else since the candidate lookup; not ours.
+				continue
+			}
+
+			cw.mu.Lock()
+			cw.categoryStreamers[cat.Slug] = append(cw.categoryStreamers[cat.Slug], candidate.Username)
+			cw.pickedAt[candidate.Username] = time.Now()
+			stats := cw.history[candidate.Username]
+			stats.picks++
+			cw.history[candidate.Username] = stats
+			slot := len(cw.categoryStreamers[cat.Slug])
+			cw.mu.Unlock()
+
+			cw.log.Info("🔍 Discovered via category",
+				"streamer", candidate.Username,
 				"category", cat.Slug,
-				"game_id", cat.GameID,
+				"viewers", candidate.ViewersCount,
+				"slot", fmt.Sprintf("%d/%d", slot, wanted),
 			)
 		}
+	}
+}
 
-		// Register game ID → slug mappings from the API response so that
-		// GameSlug() can resolve slugs for streamers outside category watch.
-		if cat.GameID != "" && cat.Slug != "" {
-			model.RegisterGameSlug(cat.GameID, cat.Slug)
+// syncDropsCategories keeps one category entry per game of the unfinished
+// drop campaigns when auto_drops_categories is enabled. Entries whose
+// campaigns have all ended or been fully claimed are dropped and their
+// streamers released. Games already listed in the config are not added again.
+func (cw *CategoryWatcher) syncDropsCategories(removeStreamer func(string, string)) {
+	if !cw.autoDrops {
+		return
+	}
+
+	games := make(map[string]*model.GameInfo)
+	for _, campaign := range cw.campaigns() {
+		if campaign.IsUnfinished() && campaign.Game != nil && campaign.Game.Slug != "" {
+			games[strings.ToLower(campaign.Game.Slug)] = campaign.Game
 		}
-		for _, s := range streams {
-			if s.GameID != "" && s.GameSlug != "" {
-				model.RegisterGameSlug(s.GameID, s.GameSlug)
+	}
+
+	known := make(map[string]bool, len(cw.categories))
+	categories := make([]categoryEntry, 0, len(cw.categories)+len(games))
+	for _, cat := range cw.categories {
+		slug := strings.ToLower(cat.Slug)
+		if cat.Auto && games[slug] == nil {
+			cw.mu.Lock()
+			usernames := slices.Clone(cw.categoryStreamers[cat.Slug])
+			cw.mu.Unlock()
+			for _, username := range usernames {
+				cw.release(cat.Slug, username, "drops_category_finished", removeStreamer)
 			}
+			cw.mu.Lock()
+			delete(cw.categoryStreamers, cat.Slug)
+			cw.mu.Unlock()
+
+			cw.log.Info("📦 Drops category finished, no longer watching",
+				"category", cat.Slug)
+			continue
 		}
+		known[slug] = true
+		categories = append(categories, cat)
+	}
 
-		existingIDs := make(map[string]bool, len(trackedStreamers))
-		for _, s := range trackedStreamers {
-			s.Mu.RLock()
-			existingIDs[s.ChannelID] = true
-			s.Mu.RUnlock()
+	dropsOnly := true
+	for _, slug := range slices.Sorted(maps.Keys(games)) {
+		if known[slug] {
+			continue
 		}
+		game := games[slug]
+		categories = append(categories, categoryEntry{
+			Slug:      game.Slug,
+			GameID:    game.ID,
+			DropsOnly: &dropsOnly,
+			Slots:     1,
+			Auto:      true,
+		})
 
-		var candidate *gql.TopStream
-		for i := range streams {
-			s := &streams[i]
-			if existingIDs[s.ChannelID] {
-				continue
-			}
-			if cw.blacklist[strings.ToLower(s.Username)] {
-				continue
+		cw.log.Info("📦 Watching drops category",
+			"category", game.Slug,
+			"game", game.DisplayName)
+	}
+
+	cw.categories = categories
+}
+
+// findCandidates returns the untracked, non-blacklisted top streams of a
+// category that pass its filter, best first. Streams in a preferred language come before all
+// others; within each group streams are ranked by viewers weighted by the
+// reliability of earlier picks. It also resolves the category's game ID.
+func (cw *CategoryWatcher) findCandidates(
+	ctx context.Context,
+	cat *categoryEntry,
+	dropsOnly bool,
+	trackedStreamers []*model.Streamer,
+) []gql.TopStream {
+	limit := 10 + cat.Slots
+
+	var match func(*gql.TopStream) bool
+	if cat.Filter != nil {
+		match = func(s *gql.TopStream) bool {
+			ok, reason := cat.Filter.Check(s.Title, s.Language, s.Tags, s.ViewersCount, s.IsMature)
+			if !ok {
+				cw.log.Debug("Category stream filtered out",
+					"category", cat.Slug,
+					"streamer", s.Username,
+					"reason", reason,
+				)
 			}
-			candidate = s
-			break
+			return ok
 		}
+	}
 
-		if candidate == nil {
-			cw.log.Info("All top streams for category are already tracked",
+	var preferred []gql.TopStream
+	if len(cat.PreferLanguages) > 0 {
+		streams, err := cw.gqlClient.GetTopStreamsByCategory(ctx, cat.Slug, gql.TopStreamsOptions{
+			Limit:     limit,
+			DropsOnly: dropsOnly,
+			Languages: cat.PreferLanguages,
+			Match:     match,
+		})
+		if err != nil {
+			cw.log.Warn("Failed to fetch top streams in preferred languages for category",
 				"category", cat.Slug,
+				"error", err,
 			)
-			continue
 		}
+		preferred = streams
+	}
 
-		streamer := model.NewStreamer(candidate.Username)
-		streamer.ChannelID = candidate.ChannelID
-		streamer.DisplayName = candidate.DisplayName
-		streamer.IsCategoryWatched = true
-		streamer.CategorySlug = cat.Slug
-
-		streamer.IsOnline = true
-		streamer.OnlineAt = time.Now()
-		stream := model.NewStream()
-		stream.Game = &model.GameInfo{
-			ID:   cat.GameID,
-			Slug: cat.Slug,
-			Name: candidate.GameName,
+	opts := gql.TopStreamsOptions{
+		Limit:     limit,
+		DropsOnly: dropsOnly,
+		Match:     match,
+	}
+	if cat.Filter != nil {
+		opts.Languages = cat.Filter.Languages
+	}
+	streams, err := cw.gqlClient.GetTopStreamsByCategory(ctx, cat.Slug, opts)
+	if err != nil {
+		cw.log.Warn("Failed to fetch top streams for category",
+			"category", cat.Slug,
+			"error", err,
+		)
+		if len(preferred) == 0 {
+			return nil
 		}
-		stream.ViewersCount = candidate.ViewersCount
-		// Fix #5: Do NOT call stream.MarkUpdated() here. The previous call
-		// prevented UpdateRequired() from returning true for 120 seconds,
-		// which meant CampaignIDs stayed empty and DropsCondition() returned
-		// false. By leaving lastUpdate at zero, the next CheckStreamerOnline
-		// cycle will call updateStream() immediately and populate CampaignIDs.
-		streamer.Stream = stream
-
-		// Fix #5 (cont): Pre-populate CampaignIDs so DropsCondition() works
-		// immediately without waiting for the next updateStream() cycle.
-		if cat.GameID != "" {
-			campaignIDs, err := cw.gqlClient.GetAvailableCampaigns(ctx, candidate.ChannelID)
-			if err == nil && len(campaignIDs) > 0 {
-				stream.CampaignIDs = campaignIDs
-			}
+	}
+
+	if len(preferred) == 0 && len(streams) == 0 {
+		filterNote := ""
+		switch {
+		case dropsOnly && cat.Filter != nil:
+			filterNote = " (drops-only and stream filters active)"
+		case dropsOnly:
+			filterNote = " (drops-only filter active)"
+		case cat.Filter != nil:
+			filterNote = " (stream filters active)"
 		}
+		cw.log.Info("No live streams for category"+filterNote,
+			"category", cat.Slug,
+		)
+		return nil
+	}
 
-		defaults := *cw.streamerDefaults
-		if defaults.Bet != nil {
-			betCopy := *defaults.Bet
-			if betCopy.FilterCondition != nil {
-				fcCopy := *betCopy.FilterCondition
-				betCopy.FilterCondition = &fcCopy
-			}
-			defaults.Bet = &betCopy
-			}
-			defaults.FollowRaid = false
-			streamer.Settings = &defaults
+	all := slices.Concat(preferred, streams)
+	if cat.GameID == "" && all[0].GameID != "" {
+		cat.GameID = all[0].GameID
+		cw.log.Info("Resolved category to game ID",
+			"category", cat.Slug,
+			"game_id", cat.GameID,
+		)
+	}
 
-		cw.mu.Lock()
-		cw.categoryStreamers[cat.Slug] = candidate.Username
-		cw.mu.Unlock()
+	// Register game ID → slug mappings from the API response so that
+	// GameSlug() can resolve slugs for streamers outside category watch.
+	if cat.GameID != "" && cat.Slug != "" {
+		model.RegisterGameSlug(cat.GameID, cat.Slug)
+	}
+	for _, s := range all {
+		if s.GameID != "" && s.GameSlug != "" {
+			model.RegisterGameSlug(s.GameID, s.GameSlug)
+		}
+	}
 
-		addStreamer(ctx, streamer)
+	skip := make(map[string]bool, len(trackedStreamers)+len(all))
+	for _, s := range trackedStreamers {
+		s.Mu.RLock()
+		skip[s.ChannelID] = true
+		s.Mu.RUnlock()
+	}
+
+	filter := func(streams []gql.TopStream) []gql.TopStream {
+		result := make([]gql.TopStream, 0, len(streams))
+		for _, s := range streams {
+			if skip[s.ChannelID] || cw.blacklist[strings.ToLower(s.Username)] {
+				continue
+			}
+			skip[s.ChannelID] = true
+			result = append(result, s)
+		}
+		cw.rank(result)
+		return result
+	}
+	candidates := slices.Concat(filter(preferred), filter(streams))
 
-		cw.log.Info("🔍 Discovered via category",
-			"streamer", candidate.Username,
+	if len(candidates) == 0 {
+		cw.log.Info("All top streams for category are already tracked",
 			"category", cat.Slug,
-			"viewers", candidate.ViewersCount,
 		)
 	}
+	return candidates
 }
 
-func (cw *CategoryWatcher) isCategoryCovered(streamers []*model.Streamer, cat *categoryEntry) bool {
+// rank sorts streams by viewers weighted by the reliability of earlier picks
+// of the same streamer, best first.
+func (cw *CategoryWatcher) rank(streams []gql.TopStream) {
+	cw.mu.Lock()
+	defer cw.mu.Unlock()
+
+	score := func(s gql.TopStream) float64 {
+		return float64(s.ViewersCount) * cw.history[s.Username].reliability()
+	}
+	slices.SortStableFunc(streams, func(a, b gql.TopStream) int {
+		return cmp.Compare(score(b), score(a))
+	})
+}
+
+// release frees the slot of a category-watched streamer and removes it. A
+// streamer that went offline or left the category shortly after being picked
+// counts as a failed pick.
+func (cw *CategoryWatcher) release(slug, username, reason string, removeStreamer func(string, string)) {
+	cw.mu.Lock()
+	cw.categoryStreamers[slug] = slices.DeleteFunc(cw.categoryStreamers[slug], func(u string) bool {
+		return u == username
+	})
+	pickedAt := cw.pickedAt[username]
+	delete(cw.pickedAt, username)
+	if (reason == "streamer_went_offline" || reason == "streamer_changed_category") &&
+		time.Since(pickedAt) < constants.CategoryPickGracePeriod {
+		stats := cw.history[username]
+		stats.failures++
+		cw.history[username] = stats
+	}
+	cw.mu.Unlock()
+
+	removeStreamer(username, reason)
+}
+
+// newStreamer builds a category-watched streamer for a top stream.
+func (cw *CategoryWatcher) newStreamer(ctx context.Context, cat *categoryEntry, candidate gql.TopStream) *model.Streamer {
+	streamer := model.NewStreamer(candidate.Username)
+	streamer.ChannelID = candidate.ChannelID
+	streamer.DisplayName = candidate.DisplayName
+	streamer.IsCategoryWatched = true
+	streamer.CategorySlug = cat.Slug
+
+	streamer.IsOnline = true
+	streamer.OnlineAt = time.Now()
+	stream := model.NewStream()
+	stream.Game = &model.GameInfo{
+		ID:   cat.GameID,
+		Slug: cat.Slug,
+		Name: candidate.GameName,
+	}
+	stream.ViewersCount = candidate.ViewersCount
+	// Fix #5: Do NOT call stream.MarkUpdated() here. The previous call
+	// prevented UpdateRequired() from returning true for 120 seconds,
+	// which meant CampaignIDs stayed empty and DropsCondition() returned
+	// false. By leaving lastUpdate at zero, the next CheckStreamerOnline
+	// cycle will call updateStream() immediately and populate CampaignIDs.
+	streamer.Stream = stream
+
+	// Fix #5 (cont): Pre-populate CampaignIDs so DropsCondition() works
+	// immediately without waiting for the next updateStream() cycle.
+	if cat.GameID != "" {
+		campaignIDs, err := cw.gqlClient.GetAvailableCampaigns(ctx, candidate.ChannelID)
+		if err == nil && len(campaignIDs) > 0 {
+			stream.CampaignIDs = campaignIDs
+		}
+	}
+
+	defaults := *cw.streamerDefaults
+	if defaults.Bet != nil {
+		betCopy := *defaults.Bet
+		if betCopy.FilterCondition != nil {
+			fcCopy := *betCopy.FilterCondition
+			betCopy.FilterCondition = &fcCopy
+		}
+		defaults.Bet = &betCopy
+	}
+	defaults.FollowRaid = false
+	streamer.Settings = &defaults
+
+	return streamer
+}
+
+// countRegularStreamers counts the online streamers outside category watch
+// that are streaming the category.
+func (cw *CategoryWatcher) countRegularStreamers(streamers []*model.Streamer, cat *categoryEntry) int {
+	count := 0
 	for _, s := range streamers {
 		s.Mu.RLock()
 		isCatWatched := s.IsCategoryWatched
@@ -301,10 +531,10 @@ func (cw *CategoryWatcher) isCategoryCovered(streamers []*model.Streamer, cat *c
 		s.Mu.RUnlock()
 
 		if !isCatWatched && isOnline && matches {
-			return true
+			count++
 		}
 	}
-	return false
+	return count
 }
 
 // checkStreamerValidity checks if a category-watched streamer is still valid.
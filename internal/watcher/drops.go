@@ -0,0 +1,387 @@
+package watcher
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Guliveer/twitch-miner-go/internal/config"
+	"github.com/Guliveer/twitch-miner-go/internal/constants"
+	"github.com/Guliveer/twitch-miner-go/internal/gql"
+	"github.com/Guliveer/twitch-miner-go/internal/logger"
+	"github.com/Guliveer/twitch-miner-go/internal/model"
+)
+
+// DropsWatcher keeps one streamer per unfinished drop campaign. Candidates
+// come from the campaign's channel allowlist or, for campaigns open to any
+// channel, from the top drops-enabled streams of the campaign's game. A
+// streamer is released once every drop of its campaign is claimed.
+type DropsWatcher struct {
+	mu sync.Mutex
+
+	gqlClient        *gql.Client
+	log              *logger.Logger
+	pollInterval     time.Duration
+	blacklist        map[string]bool
+	streamerDefaults *model.StreamerSettings
+	campaigns        func() []*model.Campaign
+	watchSelection   func() []*model.Streamer
+	streamInfo       func(context.Context, string) (*gql.StreamInfoResponse, error)
+	channelID        func(context.Context, string) (string, error)
+
+	campaignStreamers map[string]string
+}
+
+// NewDropsWatcher creates a new DropsWatcher from configuration. campaigns
+// returns the active campaigns from the latest campaign sync, and
+// watchSelection the streamers that currently get minute-watched events.
+// streamInfo and channelID look up channels through the process-wide cache
+// shared with the rest of the miner.
+func NewDropsWatcher(
+	cfg config.DropsWatcherConfig,
+	gqlClient *gql.Client,
+	log *logger.Logger,
+	blacklist []string,
+	streamerDefaults *model.StreamerSettings,
+	campaigns func() []*model.Campaign,
+	watchSelection func() []*model.Streamer,
+	streamInfo func(context.Context, string) (*gql.StreamInfoResponse, error),
+	channelID func(context.Context, string) (string, error),
+) *DropsWatcher {
+	blacklistMap := make(map[string]bool, len(blacklist))
+	for _, blacklistedName := range blacklist {
+		blacklistMap[strings.ToLower(blacklistedName)] = true
+	}
+
+	interval := cfg.PollInterval
+	if interval <= 0 {
+		interval = constants.DefaultDropsWatcherInterval
+	}
+
+	return &DropsWatcher{
+		gqlClient:         gqlClient,
+		log:               log,
+		pollInterval:      interval,
+		blacklist:         blacklistMap,
+		streamerDefaults:  streamerDefaults,
+		campaigns:         campaigns,
+		watchSelection:    watchSelection,
+		streamInfo:        streamInfo,
+		channelID:         channelID,
+		campaignStreamers: make(map[string]string),
+	}
+}
+
+// Run starts the drops watcher loop. It has the same contract as
+// CategoryWatcher.Run and blocks until the context is cancelled.
+func (dw *DropsWatcher) Run(
+	ctx context.Context,
+	addStreamer func(context.Context, *model.Streamer) bool,
+	removeStreamer func(string, string),
+	getTrackedStreamers func() []*model.Streamer,
+) error {
+	dw.log.Info("🎯 DropsWatcher started", "poll_interval", dw.pollInterval)
+
+	dw.evaluate(ctx, addStreamer, removeStreamer, getTrackedStreamers)
+
+	ticker := time.NewTicker(dw.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			dw.log.Info("🎯 DropsWatcher stopping")
+			dw.mu.Lock()
+			for campaignID, username := range dw.campaignStreamers {
+				removeStreamer(username, "drops_watcher_shutdown")
+				delete(dw.campaignStreamers, campaignID)
+			}
+			dw.mu.Unlock()
+			return ctx.Err()
+		case <-ticker.C:
+			dw.evaluate(ctx, addStreamer, removeStreamer, getTrackedStreamers)
+		}
+	}
+}
+
+// evaluate releases streamers of finished campaigns and assigns a live
+// streamer to every unfinished campaign that has none. Like the category
+// watcher, an assigned streamer is kept until it goes offline or stops
+// streaming the campaign's game.
+func (dw *DropsWatcher) evaluate(
+	ctx context.Context,
+	addStreamer func(context.Context, *model.Streamer) bool,
+	removeStreamer func(string, string),
+	getTrackedStreamers func() []*model.Streamer,
+) {
+	active := make(map[string]*model.Campaign)
+	for _, campaign := range dw.campaigns() {
+		if campaign.IsUnfinished() {
+			active[campaign.ID] = campaign
+		}
+	}
+
+	dw.mu.Lock()
+	for campaignID, username := range dw.campaignStreamers {
+		if _, ok := active[campaignID]; !ok {
+			removeStreamer(username, "drops_campaign_finished")
+			delete(dw.campaignStreamers, campaignID)
+		}
+	}
+	dw.mu.Unlock()
+
+	for _, campaign := range active {
+		if ctx.Err() != nil {
+			return
+		}
+
+		trackedStreamers := getTrackedStreamers()
+
+		dw.mu.Lock()
+		currentUsername := dw.campaignStreamers[campaign.ID]
+		dw.mu.Unlock()
+
+		if isCampaignCovered(dw.watchSelection(), campaign, currentUsername) {
+			if currentUsername != "" {
+				removeStreamer(currentUsername, "drops_campaign_covered")
+				dw.mu.Lock()
+				delete(dw.campaignStreamers, campaign.ID)
+				dw.mu.Unlock()
+			}
+			continue
+		}
+
+		if currentUsername != "" {
+			valid, reason := checkDropsStreamerValidity(trackedStreamers, currentUsername, campaign)
+			if valid {
+				continue
+			}
+			removeStreamer(currentUsername, reason)
+			dw.mu.Lock()
+			delete(dw.campaignStreamers, campaign.ID)
+			dw.mu.Unlock()
+		}
+
+		existingIDs := make(map[string]bool, len(trackedStreamers))
+		for _, s := range trackedStreamers {
+			s.Mu.RLock()
+			existingIDs[s.ChannelID] = true
+			s.Mu.RUnlock()
+		}
+
+		candidate := dw.findCandidate(ctx, campaign, existingIDs)
+		if candidate == nil {
+			dw.log.Debug("No live stream found for drop campaign",
+				"campaign", campaign.Name)
+			continue
+		}
+
+		streamer := dw.newStreamer(ctx, candidate, campaign)
+		if !addStreamer(ctx, streamer) {
+			// Tracked by someone else since the candidate lookup; not ours.
+			continue
+		}
+
+		dw.mu.Lock()
+		dw.campaignStreamers[campaign.ID] = candidate.Username
+		dw.mu.Unlock()
+
+		dw.log.Info("🔍 Discovered via drop campaign",
+			"streamer", candidate.Username,
+			"campaign", campaign.Name,
+			"viewers", candidate.ViewersCount,
+		)
+	}
+}
+
+// findCandidate returns a live stream that progresses the campaign and is
+// not tracked yet, or nil if there is none.
+func (dw *DropsWatcher) findCandidate(ctx context.Context, campaign *model.Campaign, existingIDs map[string]bool) *gql.TopStream {
+	if len(campaign.Channels) > 0 {
+		return dw.findAllowedChannel(ctx, campaign, existingIDs)
+	}
+
+	if campaign.Game == nil || campaign.Game.Slug == "" {
+		return nil
+	}
+
+	streams, err := dw.gqlClient.GetTopStreamsByCategory(ctx, campaign.Game.Slug, gql.TopStreamsOptions{
+		Limit:     10,
+		DropsOnly: true,
+	})
+	if err != nil {
+		dw.log.Warn("Failed to fetch top streams for drop campaign",
+			"campaign", campaign.Name,
+			"category", campaign.Game.Slug,
+			"error", err,
+		)
+		return nil
+	}
+
+	for i := range streams {
+		s := &streams[i]
+		if existingIDs[s.ChannelID] || dw.blacklist[strings.ToLower(s.Username)] {
+			continue
+		}
+		return s
+	}
+	return nil
+}
+
+// findAllowedChannel checks the campaign's allowlisted channels, in order,
+// for one that is live and streaming the campaign's game.
+func (dw *DropsWatcher) findAllowedChannel(ctx context.Context, campaign *model.Campaign, existingIDs map[string]bool) *gql.TopStream {
+	checked := 0
+	for i, login := range campaign.ChannelLogins {
+		if ctx.Err() != nil || checked >= constants.DropsWatcherMaxChannelChecks {
+			return nil
+		}
+
+		channelID := ""
+		if i < len(campaign.Channels) {
+			channelID = campaign.Channels[i]
+		}
+		if login == "" || existingIDs[channelID] || dw.blacklist[strings.ToLower(login)] {
+			continue
+		}
+
+		checked++
+		info, err := dw.streamInfo(ctx, login)
+		if err != nil {
+			dw.log.Debug("Failed to check allowlisted channel",
+				"streamer", login, "campaign", campaign.Name, "error", err)
+			continue
+		}
+		if info == nil || !gameMatchesCampaign(info.Game, campaign) {
+			continue
+		}
+
+		candidate := &gql.TopStream{
+			Username:     strings.ToLower(login),
+			ChannelID:    channelID,
+			DisplayName:  login,
+			ViewersCount: info.ViewersCount,
+		}
+		if info.Game != nil {
+			candidate.GameID = info.Game.ID
+			candidate.GameName = info.Game.DisplayName
+			candidate.GameSlug = info.Game.Slug
+		}
+		if candidate.ChannelID == "" {
+			id, err := dw.channelID(ctx, login)
+			if err != nil || id == "" {
+				continue
+			}
+			candidate.ChannelID = id
+		}
+		return candidate
+	}
+	return nil
+}
+
+// newStreamer builds a drops-watched streamer for a candidate stream.
+func (dw *DropsWatcher) newStreamer(ctx context.Context, candidate *gql.TopStream, campaign *model.Campaign) *model.Streamer {
+	streamer := model.NewStreamer(candidate.Username)
+	streamer.ChannelID = candidate.ChannelID
+	streamer.DisplayName = candidate.DisplayName
+	streamer.DropsCampaignID = campaign.ID
+
+	streamer.IsOnline = true
+	streamer.OnlineAt = time.Now()
+	stream := model.NewStream()
+	if campaign.Game != nil {
+		game := *campaign.Game
+		stream.Game = &game
+	}
+	stream.ViewersCount = candidate.ViewersCount
+	streamer.Stream = stream
+
+	// Pre-populate CampaignIDs so DropsCondition() holds before the next
+	// updateStream() cycle (see the category watcher).
+	campaignIDs, err := dw.gqlClient.GetAvailableCampaigns(ctx, candidate.ChannelID)
+	if err == nil && len(campaignIDs) > 0 {
+		stream.CampaignIDs = campaignIDs
+	}
+
+	defaults := *dw.streamerDefaults
+	if defaults.Bet != nil {
+		betCopy := *defaults.Bet
+		if betCopy.FilterCondition != nil {
+			fcCopy := *betCopy.FilterCondition
+			betCopy.FilterCondition = &fcCopy
+		}
+		defaults.Bet = &betCopy
+	}
+	defaults.FollowRaid = false
+	defaults.ClaimDrops = true
+	streamer.Settings = &defaults
+
+	return streamer
+}
+
+// isCampaignCovered reports whether a watched streamer other than the
+// watcher's own is online and already progressing the campaign. Only the
+// watch selection counts: a tracked streamer that gets no minute-watched
+// events makes no drop progress.
+func isCampaignCovered(streamers []*model.Streamer, campaign *model.Campaign, own string) bool {
+	for _, s := range streamers {
+		s.Mu.RLock()
+		covered := s.IsOnline &&
+			!strings.EqualFold(s.Username, own) &&
+			s.Stream != nil &&
+			slices.Contains(s.Stream.CampaignIDs, campaign.ID) &&
+			s.Settings != nil && s.Settings.ClaimDrops
+		s.Mu.RUnlock()
+
+		if covered {
+			return true
+		}
+	}
+	return false
+}
+
+// checkDropsStreamerValidity checks if a drops-watched streamer still
+// progresses its campaign. Returns (false, reason) if it should be removed.
+func checkDropsStreamerValidity(streamers []*model.Streamer, username string, campaign *model.Campaign) (bool, string) {
+	for _, s := range streamers {
+		s.Mu.RLock()
+		if s.Username != username {
+			s.Mu.RUnlock()
+			continue
+		}
+		isOnline := s.IsOnline
+		var game *model.GameInfo
+		if s.Stream != nil {
+			game = s.Stream.Game
+		}
+		matches := gameMatchesCampaign(game, campaign)
+		s.Mu.RUnlock()
+
+		if !isOnline {
+			return false, "streamer_went_offline"
+		}
+		if !matches {
+			return false, "streamer_changed_category"
+		}
+		return true, ""
+	}
+	return false, "streamer_not_found"
+}
+
+// gameMatchesCampaign reports whether a stream's game is the campaign's game.
+// Campaigns without a game match any stream.
+func gameMatchesCampaign(game *model.GameInfo, campaign *model.Campaign) bool {
+	if campaign.Game == nil {
+		return true
+	}
+	if game == nil {
+		return false
+	}
+	if campaign.Game.ID != "" && game.ID != "" {
+		return campaign.Game.ID == game.ID
+	}
+	return strings.EqualFold(campaign.Game.Slug, game.Slug) ||
+		strings.EqualFold(campaign.Game.Name, game.Name)
+}